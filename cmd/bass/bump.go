@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/vito/bass/pkg/bass"
@@ -11,61 +12,48 @@ import (
 )
 
 func bump(ctx context.Context) error {
-	return withProgress(ctx, "export", func(ctx context.Context, vertex *progrock.VertexRecorder) error {
-		lockContent, err := os.ReadFile(bumpLock)
+	return withProgress(ctx, "bump", func(ctx context.Context, vertex *progrock.VertexRecorder) error {
+		content, err := loadLockfile(bumpLock)
 		if err != nil {
 			return err
 		}
 
-		content := &proto.Memosphere{}
-		err = prototext.Unmarshal(lockContent, content)
+		filter, err := bass.ParseMemoFilter(lockOnly, inputs)
 		if err != nil {
 			return err
 		}
 
-		for _, memo := range content.Memos {
-			thunk := bass.Thunk{}
-			err := thunk.UnmarshalProto(memo.Module)
-			if err != nil {
-				return err
-			}
-
-			scope, err := bass.Bass.Load(ctx, thunk)
-			if err != nil {
-				return err
-			}
-
-			for _, call := range memo.Calls {
-				binding := bass.Symbol(call.Binding)
-
-				var comb bass.Combiner
-				err = scope.GetDecode(binding, &comb)
-				if err != nil {
-					return err
-				}
-
-				for _, res := range call.Results {
-					input, err := bass.FromProto(res.Input)
-					if err != nil {
-						return err
-					}
-
-					out, err := bass.Trampoline(ctx, comb.Call(ctx, input, bass.NewEmptyScope(), bass.Identity))
-					if err != nil {
-						return err
-					}
-
-					output, err := bass.MarshalProto(out)
-					if err != nil {
-						return err
-					}
-
-					res.Output = output
-				}
-			}
+		calls, err := bass.MemoCalls(ctx, content, filter)
+		if err != nil {
+			return err
+		}
+
+		outputs, err := bass.ResolveMemoCalls(ctx, calls, lockJobs)
+		if err != nil {
+			return err
 		}
 
-		payload, err := prototext.MarshalOptions{Multiline: true}.Marshal(content)
+		changes, err := bass.MemoChanges(calls, outputs)
+		if err != nil {
+			return err
+		}
+
+		if len(changes) == 0 {
+			fmt.Fprintln(vertex.Stdout(), "no changes")
+			return nil
+		}
+
+		bass.WriteMemoDiff(vertex.Stdout(), changes)
+
+		if bumpDryRun {
+			return nil
+		}
+
+		for i, call := range calls {
+			bass.UpdateMemoResult(call.Result, outputs[i], call.Result.Ttl.AsDuration())
+		}
+
+		payload, err := bass.MarshalMemos(content)
 		if err != nil {
 			return err
 		}
@@ -73,3 +61,18 @@ func bump(ctx context.Context) error {
 		return os.WriteFile(bumpLock, payload, 0644)
 	})
 }
+
+func loadLockfile(path string) (*proto.Memosphere, error) {
+	lockContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content := &proto.Memosphere{}
+	err = prototext.Unmarshal(lockContent, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/progrock"
+)
+
+func checkLock(ctx context.Context) error {
+	return withProgress(ctx, "check-lock", func(ctx context.Context, vertex *progrock.VertexRecorder) error {
+		content, err := loadLockfile(checkLockPath)
+		if err != nil {
+			return err
+		}
+
+		filter, err := bass.ParseMemoFilter(lockOnly, inputs)
+		if err != nil {
+			return err
+		}
+
+		calls, err := bass.MemoCalls(ctx, content, filter)
+		if err != nil {
+			return err
+		}
+
+		outputs, err := bass.ResolveMemoCalls(ctx, calls, lockJobs)
+		if err != nil {
+			return err
+		}
+
+		changes, err := bass.MemoChanges(calls, outputs)
+		if err != nil {
+			return err
+		}
+
+		if len(changes) > 0 {
+			return bass.StaleMemosError{
+				Path:  checkLockPath,
+				Memos: changes,
+			}
+		}
+
+		return nil
+	})
+}
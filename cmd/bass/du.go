@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/morikuni/aec"
+	"github.com/tonistiigi/units"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/progrock"
+)
+
+func diskUsage(ctx context.Context) error {
+	return withProgress(ctx, "du", func(ctx context.Context, vertex *progrock.VertexRecorder) error {
+		pool, err := bass.RuntimePoolFromContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		runtimes, err := pool.All()
+		if err != nil {
+			return err
+		}
+
+		w := vertex.Stdout()
+
+		for i, runtime := range runtimes {
+			usage, err := runtime.DiskUsage(ctx)
+			if err != nil {
+				return fmt.Errorf("disk usage for runtime #%d: %w", i+1, err)
+			}
+
+			fmt.Fprintf(w, "runtime #%d:\n", i+1)
+
+			err = writeDiskUsage(w, usage)
+			if err != nil {
+				return err
+			}
+		}
+
+		usage, err := bass.CacheUsage(bass.CacheHome)
+		if err != nil {
+			return fmt.Errorf("disk usage for cache: %w", err)
+		}
+
+		fmt.Fprintf(w, "cache (%s):\n", bass.CacheHome)
+
+		return writeDiskUsage(w, usage)
+	})
+}
+
+func writeDiskUsage(w io.Writer, usage []bass.DiskUsage) error {
+	tw := tabwriter.NewWriter(w, 2, 8, 2, ' ', 0)
+
+	var total int64
+	for _, du := range usage {
+		line := fmt.Sprintf("  %s", du.ID)
+
+		if du.UsageCount > 0 {
+			line += fmt.Sprintf("\tuses: %d", du.UsageCount)
+		}
+
+		if du.LastUsedAt != nil {
+			line += fmt.Sprintf("\tlast used: %s ago", bass.Clock.Since(*du.LastUsedAt).Truncate(time.Second))
+		}
+
+		line += fmt.Sprintf("\tsize: %.2f", units.Bytes(du.Size))
+
+		if du.Description != "" {
+			line += fmt.Sprintf("\t%s", aec.LightBlackF.Apply(du.Description))
+		}
+
+		fmt.Fprintln(tw, line)
+
+		total += du.Size
+	}
+
+	fmt.Fprintf(tw, "  total: %.2f\n", units.Bytes(total))
+
+	return tw.Flush()
+}
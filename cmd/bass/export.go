@@ -49,9 +49,9 @@ func export(ctx context.Context) error {
 		}
 
 		var thunk bass.Thunk
-		err = json.Unmarshal([]byte(msg), &path)
+		err = json.Unmarshal([]byte(msg), &thunk)
 		if err == nil {
-			platform := path.Thunk.Platform()
+			platform := thunk.Platform()
 			if platform == nil {
 				return fmt.Errorf("cannot export bass thunk: %s", thunk)
 			}
@@ -68,7 +68,17 @@ func export(ctx context.Context) error {
 			errs = multierror.Append(errs, err)
 		}
 
-		return fmt.Errorf("unknown payload; must be a thunk or thunk path\n%w", errs)
+		var index bass.ImageIndex
+		err = json.Unmarshal([]byte(msg), &index)
+		if err == nil && len(index.Images) > 0 {
+			return writeTar(vertex, func(w io.Writer) error {
+				return index.Export(ctx, w)
+			})
+		} else if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+
+		return fmt.Errorf("unknown payload; must be a thunk, thunk path, or image index\n%w", errs)
 	})
 }
 
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+)
+
+// gcLock runs a script, recording which memoized calls it recalls from the
+// lockfile, and prunes every result that was not recalled.
+func gcLock(ctx context.Context, argv []string) error {
+	if len(argv) == 0 {
+		err := fmt.Errorf("usage: %s --gc-lock bass.lock script.bass [args...]", os.Args[0])
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	ctx, rec := bass.RecordMemos(ctx)
+
+	// errors are written by run
+	err := run(ctx, argv[0], argv[1:]...)
+	if err != nil {
+		return err
+	}
+
+	pruned, err := bass.NewLockfileMemo(gcLockPath).Prune(rec)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "pruned %d unreferenced memos from %s\n", pruned, gcLockPath)
+
+	return nil
+}
@@ -3,11 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"path/filepath"
+	"runtime"
 	"runtime/pprof"
+	"strings"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"github.com/vito/bass/pkg/bass"
@@ -20,11 +25,26 @@ import (
 var flags = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
 var inputs []string
+var outputEncoding string
 
 var runExport bool
+var runToDockerfile bool
+var runToShellScript bool
 var bumpLock string
+var checkLockPath string
+var lockOnly []string
+var lockJobs int
+var bumpDryRun bool
+var runMergeLock bool
+var gcLockPath string
 var runPrune bool
+var pruneKeepDuration time.Duration
+var pruneKeepBytes byteSize
+var runDiskUsage bool
+var explainCache bool
 var runnerAddr string
+var memoServerPath string
+var memoServerDir string
 
 var runLSP bool
 var lspLogs string
@@ -39,14 +59,30 @@ func init() {
 	flags.SetOutput(os.Stdout)
 	flags.SortFlags = false
 
-	flags.StringSliceVarP(&inputs, "input", "i", nil, "inputs to encode as JSON on *stdin*, name=value; value may be a path")
+	flags.StringSliceVarP(&inputs, "input", "i", nil, "inputs to encode as JSON on *stdin*, name=value; value may be a path\nwith --bump or --check-lock, only memos called with the given JSON value")
+	flags.StringVarP(&outputEncoding, "output", "o", string(bass.DefaultEncoding), "encoding for values emitted to *stdout*: "+strings.Join(bass.EncodingNames(), "|"))
 
-	flags.BoolVarP(&runExport, "export", "e", false, "write a thunk path to stdout as a tar stream, or log the tar contents if stdout is a tty")
+	flags.BoolVarP(&runExport, "export", "e", false, "write a thunk, thunk path, or image index to stdout as a tar stream, or log the tar contents if stdout is a tty")
+	flags.BoolVar(&runToDockerfile, "to-dockerfile", false, "write a Dockerfile equivalent to a thunk read from stdin")
+	flags.BoolVar(&runToShellScript, "to-sh", false, "write a shell script equivalent to a thunk read from stdin")
 	flags.StringVarP(&bumpLock, "bump", "b", "", "re-generate all values in a bass.lock file")
+	flags.StringVar(&checkLockPath, "check-lock", "", "verify that all values in a bass.lock file are up-to-date, without writing")
+	flags.StringSliceVar(&lockOnly, "only", nil, "with --bump or --check-lock, only memos for the given module or module:binding")
+	flags.IntVarP(&lockJobs, "jobs", "j", runtime.NumCPU(), "with --bump or --check-lock, number of memos to resolve in parallel")
+	flags.BoolVar(&bumpDryRun, "dry-run", false, "with --bump, print changes without writing them")
+	flags.StringVar(&gcLockPath, "gc-lock", "", "run a script and prune memos it does not recall from the given bass.lock file")
+	flags.BoolVar(&runMergeLock, "merge-lock", false, "merge bass.lock files given as base ours theirs, writing to ours (git merge driver)")
 
-	flags.BoolVarP(&runPrune, "prune", "p", false, "release data and caches retained by runtimes")
+	flags.BoolVar(&explainCache, "explain-cache", false, "explain which fields changed for each thunk that misses the cache")
+
+	flags.BoolVarP(&runPrune, "prune", "p", false, "release data and caches retained by runtimes and the local cache")
+	flags.DurationVar(&pruneKeepDuration, "keep-duration", 0, "with --prune, keep data used within the given duration, e.g. 72h; combined with --keep-bytes, only older data is removed to stay within the size")
+	flags.Var(&pruneKeepBytes, "keep-bytes", "with --prune, stop removing data once the total is within the given size, e.g. 20GB")
+	flags.BoolVar(&runDiskUsage, "du", false, "show disk usage of runtimes and the local cache")
 
 	flags.StringVarP(&runnerAddr, "runner", "r", "", "serve locally configured runtimes over SSH")
+	flags.StringVar(&memoServerPath, "memo-server", "", "serve memos shared by other bass processes on the given Unix socket")
+	flags.StringVar(&memoServerDir, "memo-server-dir", "", "with --memo-server, directory to store memos in (default: memos under the cache dir)")
 
 	flags.BoolVar(&runLSP, "lsp", false, "run the bass language server")
 	flags.StringVar(&lspLogs, "lsp-log-file", "", "write language server logs to this file")
@@ -125,6 +161,30 @@ func root(ctx context.Context) error {
 		defer pprof.StopCPUProfile()
 	}
 
+	if runMergeLock {
+		// no runtimes needed; merge drivers may run in environments without any
+		return mergeLock(ctx, flags.Args())
+	}
+
+	if runToDockerfile {
+		// no runtimes needed; the thunk is only rendered
+		return toDockerfile(ctx)
+	}
+
+	if runToShellScript {
+		return toShellScript(ctx)
+	}
+
+	if memoServerPath != "" {
+		return memoServer(ctx, memoServerPath, memoServerDir)
+	}
+
+	if _, found := bass.Encodings[bass.Symbol(outputEncoding)]; !found {
+		err := bass.UnknownEncodingError{Encoding: bass.Symbol(outputEncoding)}
+		cli.WriteError(ctx, err)
+		return err
+	}
+
 	config, err := bass.LoadConfig(DefaultConfig)
 	if err != nil {
 		cli.WriteError(ctx, err)
@@ -139,6 +199,32 @@ func root(ctx context.Context) error {
 
 	ctx = bass.WithRuntimePool(ctx, pool)
 
+	if explainCache {
+		ctx = bass.WithCacheExplainer(ctx, bass.NewCacheExplainer(filepath.Join(bass.CacheHome, "fingerprints")))
+	}
+
+	if config.Cache != nil {
+		bass.CacheMaxBytes = config.Cache.MaxBytes
+	}
+
+	if config.Privileges != nil {
+		ctx = bass.WithPrivileges(ctx, *config.Privileges)
+	}
+
+	if config.Memos != nil {
+		memos, err := bass.NewMemos(ctx, *config.Memos)
+		if err != nil {
+			cli.WriteError(ctx, err)
+			return err
+		}
+
+		if closer, ok := memos.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		ctx = bass.WithMemos(ctx, memos)
+	}
+
 	if runnerAddr != "" {
 		return runnerLoop(ctx, runnerAddr, pool.Runtimes)
 	}
@@ -151,6 +237,10 @@ func root(ctx context.Context) error {
 		return prune(ctx)
 	}
 
+	if runDiskUsage {
+		return diskUsage(ctx)
+	}
+
 	if runLSP {
 		return langServer(ctx)
 	}
@@ -159,6 +249,14 @@ func root(ctx context.Context) error {
 		return bump(ctx)
 	}
 
+	if checkLockPath != "" {
+		return checkLock(ctx)
+	}
+
+	if gcLockPath != "" {
+		return gcLock(ctx, flags.Args())
+	}
+
 	argv := flags.Args()
 
 	if len(argv) == 0 {
@@ -171,12 +269,29 @@ func root(ctx context.Context) error {
 func repl(ctx context.Context) error {
 	env := bass.ImportSystemEnv()
 
+	stdout, err := stdoutSink("stdout", os.Stdout)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
 	scope := bass.NewRunScope(bass.Ground, bass.RunState{
 		Dir:    bass.NewHostDir("."),
 		Stdin:  bass.Stdin,
-		Stdout: bass.Stdout,
+		Stdout: stdout,
 		Env:    env,
 	})
 
 	return cli.Repl(ctx, scope)
 }
+
+// stdoutSink returns a sink writing to w using the configured --output
+// encoding.
+func stdoutSink(name string, w io.Writer) (*bass.Sink, error) {
+	sink, err := bass.NewEncodingSink(bass.Symbol(outputEncoding), name, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return bass.NewSink(sink), nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/proto"
+	"github.com/vito/bass/pkg/zapctx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// memoServer serves memos stored in dir on a Unix socket, so that they can be
+// shared by any bass process configured to use it:
+//
+//	{"memos": {"backend": "socket", "path": "/path/to/memos.sock"}}
+//
+// If dir is empty, memos are stored under the cache directory.
+func memoServer(ctx context.Context, socketPath string, dir string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	logger := zapctx.FromContext(ctx)
+
+	// clean up a socket left behind by a previous server
+	err := os.Remove(socketPath)
+	if err != nil && !os.IsNotExist(err) {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	if dir == "" {
+		dir = filepath.Join(bass.CacheHome, "memos")
+	}
+
+	srv := grpc.NewServer()
+	proto.RegisterMemosServer(srv, &bass.MemoServer{
+		Memos: bass.NewDirMemos(dir),
+	})
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	logger.Info("serving memos",
+		zap.String("socket", socketPath),
+		zap.String("dir", dir))
+
+	err = srv.Serve(l)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+)
+
+// mergeLock performs a three-way merge of bass.lock files, writing the result
+// to ours.
+//
+// It is suitable for use as a git merge driver:
+//
+//	git config merge.bass-lock.driver "bass --merge-lock %O %A %B"
+//	echo 'bass.lock merge=bass-lock' >> .gitattributes
+func mergeLock(ctx context.Context, argv []string) error {
+	if len(argv) != 3 {
+		err := fmt.Errorf("usage: %s --merge-lock base ours theirs", os.Args[0])
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	basePath, oursPath, theirsPath := argv[0], argv[1], argv[2]
+
+	base, err := loadLockfile(basePath)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	ours, err := loadLockfile(oursPath)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	theirs, err := loadLockfile(theirsPath)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	merged, conflicts, err := bass.MergeMemos(base, ours, theirs)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	payload, err := bass.MarshalMemos(merged)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	err = os.WriteFile(oursPath, payload, 0644)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		err := bass.MemoConflictsError{
+			Path:      oursPath,
+			Conflicts: conflicts,
+		}
+
+		cli.WriteError(ctx, err)
+
+		return err
+	}
+
+	return nil
+}
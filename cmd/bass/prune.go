@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/progrock"
@@ -20,13 +22,79 @@ func prune(ctx context.Context) error {
 			return err
 		}
 
+		opts := bass.PruneOpts{
+			KeepDuration: pruneKeepDuration,
+			KeepBytes:    int64(pruneKeepBytes),
+		}
+
 		for i, runtime := range runtimes {
-			err := runtime.Prune(ctx, bass.PruneOpts{})
+			err := runtime.Prune(ctx, opts)
 			if err != nil {
 				return fmt.Errorf("prune runtime #%d: %w", i+1, err)
 			}
 		}
 
+		if pruneKeepDuration == 0 && pruneKeepBytes == 0 {
+			err = bass.PruneCache(bass.CacheHome)
+		} else {
+			err = bass.PruneCacheKeeping(bass.CacheHome, pruneKeepDuration, int64(pruneKeepBytes))
+		}
+		if err != nil {
+			return fmt.Errorf("prune cache: %w", err)
+		}
+
 		return nil
 	})
 }
+
+// byteSize is a flag value for a number of bytes with an optional unit, e.g.
+// 512MB or 20GiB.
+type byteSize int64
+
+var byteUnits = []struct {
+	Suffix string
+	Size   int64
+}{
+	// longest suffixes first so that e.g. KiB is not matched as B
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"TiB", 1 << 40},
+	{"KB", 1e3},
+	{"MB", 1e6},
+	{"GB", 1e9},
+	{"TB", 1e12},
+	{"K", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"B", 1},
+}
+
+func (size *byteSize) Set(str string) error {
+	num, mult := str, int64(1)
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(strings.ToUpper(str), strings.ToUpper(unit.Suffix)) {
+			num = strings.TrimSpace(str[:len(str)-len(unit.Suffix)])
+			mult = unit.Size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid size: %q", str)
+	}
+
+	*size = byteSize(n * float64(mult))
+
+	return nil
+}
+
+func (size byteSize) String() string {
+	return strconv.FormatInt(int64(size), 10)
+}
+
+func (size byteSize) Type() string {
+	return "bytes"
+}
@@ -41,9 +41,14 @@ func run(ctx context.Context, filePath string, argv ...string) error {
 			}()
 		}
 
-		stdout := bass.Stdout
+		var stdout *bass.Sink
 		if isTerm {
-			stdout = bass.NewSink(bass.NewJSONSink("stdout vertex", bassVertex.Stdout()))
+			stdout, err = stdoutSink("stdout vertex", bassVertex.Stdout())
+		} else {
+			stdout, err = stdoutSink("stdout", os.Stdout)
+		}
+		if err != nil {
+			return
 		}
 
 		env := bass.ImportSystemEnv()
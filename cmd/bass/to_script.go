@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/cli"
+	"github.com/vito/bass/pkg/runtimes"
+)
+
+// toScript reads a thunk from stdin and renders it to stdout using the given
+// exporter, e.g. as a Dockerfile or a shell script.
+func toScript(ctx context.Context, render func(io.Writer, bass.Thunk) error) error {
+	dec := bass.NewRawDecoder(os.Stdin)
+
+	var thunk bass.Thunk
+	err := dec.Decode(&thunk)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	err = render(os.Stdout, thunk)
+	if err != nil {
+		cli.WriteError(ctx, err)
+		return err
+	}
+
+	return nil
+}
+
+func toDockerfile(ctx context.Context) error {
+	return toScript(ctx, runtimes.ExportDockerfile)
+}
+
+func toShellScript(ctx context.Context) error {
+	return toScript(ctx, runtimes.ExportShellScript)
+}
@@ -11,6 +11,7 @@ require (
 	github.com/cenkalti/backoff/v4 v4.1.2
 	github.com/containerd/containerd v1.6.1
 	github.com/docker/distribution v2.8.0+incompatible
+	github.com/docker/go-units v0.4.0
 	github.com/gertd/go-pluralize v0.1.7
 	github.com/gofrs/flock v0.8.1
 	github.com/google/go-cmp v0.5.7
@@ -44,6 +45,7 @@ require (
 	golang.org/x/term v0.0.0-20210615171337-6886f2dfbf5b
 	google.golang.org/grpc v1.44.0
 	google.golang.org/protobuf v1.27.1
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require (
@@ -108,6 +110,7 @@ require (
 	golang.org/x/tools v0.1.5 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	google.golang.org/genproto v0.0.0-20211208223120-3a66f561d7aa // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
 
 // keep in sync with upstream buildkit
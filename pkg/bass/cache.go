@@ -2,10 +2,17 @@ package bass
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/adrg/xdg"
 )
@@ -13,17 +20,48 @@ import (
 // CacheHome is the directory where Bass stores caches.
 var CacheHome string
 
+// CacheMaxBytes limits the total size of the files written by Cache under a
+// cache root. The least recently used files are evicted to stay within the
+// limit.
+//
+// Zero means no limit.
+var CacheMaxBytes int64
+
 func init() {
 	CacheHome = filepath.Join(xdg.CacheHome, "bass")
 }
 
+// cacheDirs are the directories under a cache root which are managed by
+// Cache.
+var cacheDirs = []string{"fs", "thunk-paths", "thunk-outputs"}
+
+// digestSuffix is appended to a cached file's path to form the path of a file
+// containing its SHA256 digest.
+const digestSuffix = ".sha256"
+
 // Cache exports a readable file path to a local path if it does not already
 // exist.
 //
+// The cache path must be of the form <root>/<dir>/<name>. Files are written
+// atomically along with their digest, which is verified the first time the
+// file is used by this process; a file which does not match its digest is
+// written again. After writing, the least recently used files under the root
+// are evicted to stay within CacheMaxBytes.
+//
 // Does not preserve file permissions and timestamps. Only use for accessing
 // the content of a Readable.
 func Cache(ctx context.Context, cachePath string, rd Readable) (string, error) {
-	if _, err := os.Stat(cachePath); err == nil {
+	index, err := loadCacheIndex(filepath.Dir(filepath.Dir(cachePath)))
+	if err != nil {
+		return "", fmt.Errorf("cache: index: %w", err)
+	}
+
+	if index.verify(cachePath) {
+		err := index.touch(cachePath)
+		if err != nil {
+			return "", fmt.Errorf("cache: touch: %w", err)
+		}
+
 		return cachePath, nil
 	}
 
@@ -39,17 +77,486 @@ func Cache(ctx context.Context, cachePath string, rd Readable) (string, error) {
 		return "", fmt.Errorf("cache: mkdir parent: %w", err)
 	}
 
-	cacheFile, err := os.Create(cachePath)
+	hash := sha256.New()
+	err = writeFileAtomic(cachePath, io.TeeReader(rc, hash))
 	if err != nil {
 		return "", fmt.Errorf("create cache: %w", err)
 	}
 
-	defer cacheFile.Close()
+	digest := hex.EncodeToString(hash.Sum(nil))
+	err = writeFileAtomic(cachePath+digestSuffix, strings.NewReader(digest))
+	if err != nil {
+		return "", fmt.Errorf("create cache digest: %w", err)
+	}
 
-	_, err = io.Copy(cacheFile, rc)
+	err = index.add(cachePath)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("cache: touch: %w", err)
+	}
+
+	if CacheMaxBytes > 0 {
+		err = index.evict(CacheMaxBytes, cachePath)
+		if err != nil {
+			return "", fmt.Errorf("evict cache: %w", err)
+		}
 	}
 
 	return cachePath, nil
 }
+
+// EvictCache removes the least recently used files written by Cache under the
+// root until their total size is within maxBytes.
+func EvictCache(root string, maxBytes int64) error {
+	defer forgetCacheIndex(root)
+	return evictCache(root, maxBytes, "")
+}
+
+// evictCache evicts files like EvictCache, but never evicts the file at keep
+// so that a file may be used immediately after it is cached.
+func evictCache(root string, maxBytes int64, keep string) error {
+	entries, err := cacheEntries(root)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.info.Size()
+	}
+
+	for _, e := range entries {
+		if total <= maxBytes {
+			break
+		}
+
+		if e.path == keep {
+			continue
+		}
+
+		err := removeCacheEntry(e.path)
+		if err != nil {
+			return err
+		}
+
+		total -= e.info.Size()
+	}
+
+	return nil
+}
+
+// PruneCacheKeeping removes the least recently used files written by Cache
+// under the root, applying both options together as Buildkit does.
+//
+// Files last used within keepDuration are always kept. If keepBytes is
+// non-zero, older files are only removed until the total size is within
+// keepBytes.
+func PruneCacheKeeping(root string, keepDuration time.Duration, keepBytes int64) error {
+	defer forgetCacheIndex(root)
+
+	entries, err := cacheEntries(root)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, e := range entries {
+		total += e.info.Size()
+	}
+
+	cutoff := Clock.Now().Add(-keepDuration)
+
+	for _, e := range entries {
+		if keepBytes > 0 && total <= keepBytes {
+			break
+		}
+
+		if keepDuration > 0 && !e.info.ModTime().Before(cutoff) {
+			// sorted by last use, so the rest are newer
+			break
+		}
+
+		err := removeCacheEntry(e.path)
+		if err != nil {
+			return err
+		}
+
+		total -= e.info.Size()
+	}
+
+	return nil
+}
+
+type cacheEntry struct {
+	path string
+	info fs.FileInfo
+}
+
+// cacheEntries returns every file written by Cache under the root, least
+// recently used first.
+func cacheEntries(root string) ([]cacheEntry, error) {
+	var entries []cacheEntry
+	for _, dir := range cacheDirs {
+		err := filepath.WalkDir(filepath.Join(root, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if !d.Type().IsRegular() || strings.HasSuffix(path, digestSuffix) {
+				return nil
+			}
+
+			if strings.HasPrefix(d.Name(), ".") {
+				// in-progress write
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					// evicted concurrently
+					return nil
+				}
+
+				return err
+			}
+
+			entries = append(entries, cacheEntry{path, info})
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].info.ModTime().Before(entries[j].info.ModTime())
+	})
+
+	return entries, nil
+}
+
+// removeCacheEntry removes a file written by Cache along with its digest.
+func removeCacheEntry(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	err = os.Remove(path + digestSuffix)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// PruneCache removes all files written by Cache under the root.
+func PruneCache(root string) error {
+	defer forgetCacheIndex(root)
+
+	for _, dir := range cacheDirs {
+		err := os.RemoveAll(filepath.Join(root, dir))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CacheUsage returns the disk usage of each directory of files written by
+// Cache under the root.
+func CacheUsage(root string) ([]DiskUsage, error) {
+	var usage []DiskUsage
+	for _, dir := range cacheDirs {
+		du := DiskUsage{
+			ID:          dir,
+			Description: filepath.Join(root, dir),
+		}
+
+		var lastUsed time.Time
+		err := filepath.WalkDir(du.Description, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			du.Size += info.Size()
+
+			if info.ModTime().After(lastUsed) {
+				lastUsed = info.ModTime()
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !lastUsed.IsZero() {
+			du.LastUsedAt = &lastUsed
+		}
+
+		usage = append(usage, du)
+	}
+
+	return usage, nil
+}
+
+// cacheIndex tracks the files written by Cache under a root, so that eviction
+// does not walk the cache on every write and files are not hashed on every
+// use.
+//
+// The index is loaded from disk the first time the root is used by this
+// process.
+type cacheIndex struct {
+	entries map[string]*cacheIndexEntry
+	total   int64
+	l       sync.Mutex
+}
+
+type cacheIndexEntry struct {
+	size     int64
+	lastUsed time.Time
+
+	// verified is set once the file has been checked against its digest
+	verified bool
+}
+
+var cacheIndexes = map[string]*cacheIndex{}
+var cacheIndexesL sync.Mutex
+
+func loadCacheIndex(root string) (*cacheIndex, error) {
+	cacheIndexesL.Lock()
+	defer cacheIndexesL.Unlock()
+
+	index, found := cacheIndexes[root]
+	if found {
+		return index, nil
+	}
+
+	entries, err := cacheEntries(root)
+	if err != nil {
+		return nil, err
+	}
+
+	index = &cacheIndex{
+		entries: map[string]*cacheIndexEntry{},
+	}
+
+	for _, e := range entries {
+		index.entries[e.path] = &cacheIndexEntry{
+			size:     e.info.Size(),
+			lastUsed: e.info.ModTime(),
+		}
+
+		index.total += e.info.Size()
+	}
+
+	cacheIndexes[root] = index
+
+	return index, nil
+}
+
+// forgetCacheIndex discards the index for the root so that it is loaded again
+// after the files under it have been modified externally.
+func forgetCacheIndex(root string) {
+	cacheIndexesL.Lock()
+	delete(cacheIndexes, root)
+	cacheIndexesL.Unlock()
+}
+
+// verify returns true if the file exists and matches its digest.
+//
+// The digest is only checked once; after that, the file is assumed to be
+// intact as long as its size has not changed.
+func (index *cacheIndex) verify(cachePath string) bool {
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return false
+	}
+
+	index.l.Lock()
+	entry, found := index.entries[cachePath]
+	verified := found && entry.verified && entry.size == info.Size()
+	index.l.Unlock()
+
+	if verified {
+		return true
+	}
+
+	if !verifyCache(cachePath) {
+		return false
+	}
+
+	index.l.Lock()
+	index.set(cachePath, info.Size(), info.ModTime())
+	index.entries[cachePath].verified = true
+	index.l.Unlock()
+
+	return true
+}
+
+// touch marks the file as used at the current time.
+func (index *cacheIndex) touch(cachePath string) error {
+	err := touchCache(cachePath)
+	if err != nil {
+		return err
+	}
+
+	index.l.Lock()
+	if entry, found := index.entries[cachePath]; found {
+		entry.lastUsed = Clock.Now()
+	}
+	index.l.Unlock()
+
+	return nil
+}
+
+// add records a file which was just written and verified.
+func (index *cacheIndex) add(cachePath string) error {
+	err := touchCache(cachePath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		return err
+	}
+
+	index.l.Lock()
+	index.set(cachePath, info.Size(), Clock.Now())
+	index.entries[cachePath].verified = true
+	index.l.Unlock()
+
+	return nil
+}
+
+// set records the size and last use of a file. The index must be locked.
+func (index *cacheIndex) set(cachePath string, size int64, lastUsed time.Time) {
+	entry, found := index.entries[cachePath]
+	if !found {
+		entry = &cacheIndexEntry{}
+		index.entries[cachePath] = entry
+	}
+
+	index.total += size - entry.size
+	entry.size = size
+	entry.lastUsed = lastUsed
+}
+
+// evict removes the least recently used files until their total size is
+// within maxBytes, never evicting the file at keep.
+func (index *cacheIndex) evict(maxBytes int64, keep string) error {
+	index.l.Lock()
+	defer index.l.Unlock()
+
+	if index.total <= maxBytes {
+		return nil
+	}
+
+	paths := make([]string, 0, len(index.entries))
+	for path := range index.entries {
+		paths = append(paths, path)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return index.entries[paths[i]].lastUsed.Before(index.entries[paths[j]].lastUsed)
+	})
+
+	for _, path := range paths {
+		if index.total <= maxBytes {
+			break
+		}
+
+		if path == keep {
+			continue
+		}
+
+		err := removeCacheEntry(path)
+		if err != nil {
+			return err
+		}
+
+		index.total -= index.entries[path].size
+		delete(index.entries, path)
+	}
+
+	return nil
+}
+
+// touchCache sets the file's modification time to the current time, which is
+// used as its last use for LRU eviction.
+func touchCache(cachePath string) error {
+	now := Clock.Now()
+	return os.Chtimes(cachePath, now, now)
+}
+
+// verifyCache returns true if the file exists and matches its digest.
+func verifyCache(cachePath string) bool {
+	expected, err := os.ReadFile(cachePath + digestSuffix)
+	if err != nil {
+		return false
+	}
+
+	file, err := os.Open(cachePath)
+	if err != nil {
+		return false
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return false
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)) == string(expected)
+}
+
+// writeFileAtomic writes to a temporary file and renames it to the path, so
+// that a partially written file is never observed.
+func writeFileAtomic(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,248 @@
+package bass_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestCache(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	cachePath := filepath.Join(t.TempDir(), "fs", "file")
+
+	path, err := bass.Cache(ctx, cachePath, bass.NewInMemoryFile("file", "hello"))
+	is.NoErr(err)
+	is.Equal(path, cachePath)
+
+	content, err := os.ReadFile(cachePath)
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+
+	// cached files are not written again
+	_, err = bass.Cache(ctx, cachePath, bass.NewInMemoryFile("file", "goodbye"))
+	is.NoErr(err)
+
+	content, err = os.ReadFile(cachePath)
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+
+	// truncated files fail the digest check and are written again
+	is.NoErr(os.WriteFile(cachePath, []byte("hel"), 0600))
+
+	_, err = bass.Cache(ctx, cachePath, bass.NewInMemoryFile("file", "hello"))
+	is.NoErr(err)
+
+	content, err = os.ReadFile(cachePath)
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+}
+
+func TestCacheEviction(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	clock := clockwork.NewFakeClock()
+	defer func(orig clockwork.Clock) { bass.Clock = orig }(bass.Clock)
+	bass.Clock = clock
+
+	defer func(orig int64) { bass.CacheMaxBytes = orig }(bass.CacheMaxBytes)
+	bass.CacheMaxBytes = 10
+
+	root := t.TempDir()
+
+	cache := func(name string) {
+		_, err := bass.Cache(ctx, filepath.Join(root, "fs", name), bass.NewInMemoryFile(name, "12345"))
+		is.NoErr(err)
+		clock.Advance(time.Minute)
+	}
+
+	cached := func(name string) bool {
+		_, err := os.Stat(filepath.Join(root, "fs", name))
+		return err == nil
+	}
+
+	cache("a")
+	cache("b")
+	cache("a") // use a, so that b is the least recently used
+	cache("c")
+
+	is.True(cached("a"))
+	is.True(!cached("b"))
+	is.True(cached("c"))
+
+	// the file being cached is never evicted, even if it exceeds the limit
+	_, err := bass.Cache(ctx, filepath.Join(root, "fs", "big"), bass.NewInMemoryFile("big", "0123456789abcdef"))
+	is.NoErr(err)
+	is.True(cached("big"))
+	is.True(!cached("a"))
+	is.True(!cached("c"))
+
+	// other files under the root are left alone
+	is.NoErr(os.MkdirAll(filepath.Join(root, "memos"), 0700))
+	is.NoErr(os.WriteFile(filepath.Join(root, "memos", "x"), []byte("0123456789"), 0600))
+
+	is.NoErr(bass.EvictCache(root, 0))
+	is.True(!cached("big"))
+
+	_, err = os.Stat(filepath.Join(root, "memos", "x"))
+	is.NoErr(err)
+}
+
+func TestCacheEvictionExisting(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	clock := clockwork.NewFakeClock()
+	defer func(orig clockwork.Clock) { bass.Clock = orig }(bass.Clock)
+	bass.Clock = clock
+
+	defer func(orig int64) { bass.CacheMaxBytes = orig }(bass.CacheMaxBytes)
+	bass.CacheMaxBytes = 10
+
+	root := t.TempDir()
+
+	// cached by a previous process
+	old := filepath.Join(root, "fs", "old")
+	is.NoErr(os.MkdirAll(filepath.Dir(old), 0700))
+	is.NoErr(os.WriteFile(old, []byte("12345"), 0600))
+	is.NoErr(os.Chtimes(old, clock.Now().Add(-time.Hour), clock.Now().Add(-time.Hour)))
+
+	_, err := bass.Cache(ctx, filepath.Join(root, "fs", "a"), bass.NewInMemoryFile("a", "12345"))
+	is.NoErr(err)
+
+	_, err = os.Stat(old)
+	is.NoErr(err)
+
+	clock.Advance(time.Minute)
+
+	_, err = bass.Cache(ctx, filepath.Join(root, "fs", "b"), bass.NewInMemoryFile("b", "12345"))
+	is.NoErr(err)
+
+	// files cached before the process started count towards the limit
+	_, err = os.Stat(old)
+	is.True(os.IsNotExist(err))
+}
+
+func TestPruneCache(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	root := t.TempDir()
+
+	cachePath := filepath.Join(root, "thunk-outputs", "file")
+	_, err := bass.Cache(ctx, cachePath, bass.NewInMemoryFile("file", "hello"))
+	is.NoErr(err)
+
+	is.NoErr(bass.PruneCache(root))
+
+	_, err = os.Stat(cachePath)
+	is.True(os.IsNotExist(err))
+
+	// pruned files are written again
+	_, err = bass.Cache(ctx, cachePath, bass.NewInMemoryFile("file", "hello"))
+	is.NoErr(err)
+
+	content, err := os.ReadFile(cachePath)
+	is.NoErr(err)
+	is.Equal(string(content), "hello")
+}
+
+func TestPruneCacheKeeping(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	clock := clockwork.NewFakeClock()
+	defer func(orig clockwork.Clock) { bass.Clock = orig }(bass.Clock)
+	bass.Clock = clock
+
+	root := t.TempDir()
+
+	cache := func(name string) {
+		_, err := bass.Cache(ctx, filepath.Join(root, "fs", name), bass.NewInMemoryFile(name, "hello"))
+		is.NoErr(err)
+	}
+
+	cached := func(name string) bool {
+		_, err := os.Stat(filepath.Join(root, "fs", name))
+		return err == nil
+	}
+
+	cache("old")
+	clock.Advance(48 * time.Hour)
+	cache("recent")
+	clock.Advance(time.Hour)
+
+	is.NoErr(bass.PruneCacheKeeping(root, 24*time.Hour, 0))
+	is.True(!cached("old"))
+	is.True(cached("recent"))
+
+	_, err := os.Stat(filepath.Join(root, "fs", "old.sha256"))
+	is.True(os.IsNotExist(err))
+
+	cache("a")
+	clock.Advance(time.Hour)
+	cache("b")
+	clock.Advance(time.Hour)
+	cache("c")
+	clock.Advance(48 * time.Hour)
+	cache("d")
+
+	// both options apply together: only data older than the duration is
+	// removed, and only until the total is within the size
+	is.NoErr(bass.PruneCacheKeeping(root, 24*time.Hour, int64(len("hello")*3)))
+	is.True(!cached("recent"))
+	is.True(!cached("a"))
+	is.True(cached("b"))
+	is.True(cached("c"))
+	is.True(cached("d"))
+
+	// data used within the duration is kept even if it exceeds the size
+	is.NoErr(bass.PruneCacheKeeping(root, 24*time.Hour, 1))
+	is.True(!cached("b"))
+	is.True(!cached("c"))
+	is.True(cached("d"))
+
+	// with no duration, the least recently used data is removed until the
+	// total is within the size
+	cache("e")
+	is.NoErr(bass.PruneCacheKeeping(root, 0, int64(len("hello"))))
+	is.True(!cached("d"))
+	is.True(cached("e"))
+}
+
+func TestCacheUsage(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	root := t.TempDir()
+
+	_, err := bass.Cache(ctx, filepath.Join(root, "fs", "file"), bass.NewInMemoryFile("file", "hello"))
+	is.NoErr(err)
+
+	usage, err := bass.CacheUsage(root)
+	is.NoErr(err)
+
+	sizes := map[string]int64{}
+	for _, du := range usage {
+		sizes[du.ID] = du.Size
+	}
+
+	// content and its digest
+	is.Equal(sizes["fs"], int64(len("hello")+64))
+	is.Equal(sizes["thunk-paths"], int64(0))
+	is.Equal(sizes["thunk-outputs"], int64(0))
+}
@@ -13,6 +13,52 @@ import (
 // run on the same machine.
 type Config struct {
 	Runtimes []RuntimeConfig `json:"runtimes"`
+
+	// Memos configures a shared backend for memoized calls, layered under
+	// bass.lock files on the host; see LayeredMemos.
+	//
+	// Lockfiles are still written, recording every result that was used,
+	// including results found in the shared backend.
+	Memos *MemosConfig `json:"memos,omitempty"`
+
+	// Cache configures the local cache under CacheHome.
+	Cache *CacheConfig `json:"cache,omitempty"`
+
+	// Privileges configures which capabilities and devices scripts may
+	// request for their thunks.
+	Privileges *PrivilegesConfig `json:"privileges,omitempty"`
+}
+
+// PrivilegesConfig allowlists the capabilities and devices which may be
+// requested by scripts with (with-capabilities) and (with-device). Nothing is
+// allowed by default.
+type PrivilegesConfig struct {
+	// Capabilities lists Linux capability names like "net-admin".
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Devices lists device paths like "/dev/fuse".
+	Devices []string `json:"devices,omitempty"`
+}
+
+// CacheConfig configures the local cache.
+type CacheConfig struct {
+	// MaxBytes limits the total size of cached files. The least recently used
+	// files are evicted to stay within the limit.
+	MaxBytes int64 `json:"max_bytes,omitempty"`
+}
+
+// MemosConfig selects a memo backend by name.
+//
+// Memos stored in a backend are shared by every script run with the same
+// config, so several checkouts of a repo can share resolved results. The
+// backend is used alongside each bass.lock file rather than in place of it;
+// see LayeredMemos.
+type MemosConfig struct {
+	// Backend is one of "dir", "json", or "socket".
+	Backend string `json:"backend"`
+
+	// Path is the directory, file, or Unix socket used by the backend.
+	Path string `json:"path"`
 }
 
 // RuntimeConfig associates a platform object to a runtime command to run.
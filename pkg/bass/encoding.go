@@ -0,0 +1,199 @@
+package bass
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Encoding constructs a sink which writes values to w in a particular format.
+type Encoding func(name string, w io.Writer) PipeSink
+
+// Encodings defines the set of supported encodings for emitting values, e.g.
+// to *stdout*.
+var Encodings = map[Symbol]Encoding{
+	"json": func(name string, w io.Writer) PipeSink {
+		return NewIndentedJSONSink(name, w)
+	},
+	"jsonl": func(name string, w io.Writer) PipeSink {
+		return NewJSONSink(name, w)
+	},
+	"yaml": func(name string, w io.Writer) PipeSink {
+		return NewYAMLSink(name, w)
+	},
+	"raw": func(name string, w io.Writer) PipeSink {
+		return NewRawSink(name, w)
+	},
+	"table": func(name string, w io.Writer) PipeSink {
+		return NewTableSink(name, w)
+	},
+}
+
+// DefaultEncoding is the encoding used for *stdout* unless otherwise
+// configured.
+const DefaultEncoding Symbol = "jsonl"
+
+// EncodingNames returns the names of all supported encodings, sorted.
+func EncodingNames() []string {
+	names := make([]string, 0, len(Encodings))
+	for name := range Encodings {
+		names = append(names, name.String())
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewEncodingSink uses the named encoding to construct a sink writing to w.
+func NewEncodingSink(encoding Symbol, name string, w io.Writer) (PipeSink, error) {
+	enc, found := Encodings[encoding]
+	if !found {
+		return nil, UnknownEncodingError{encoding}
+	}
+
+	return enc(name, w), nil
+}
+
+// UnknownEncodingError is returned when an unknown output encoding is
+// requested.
+type UnknownEncodingError struct {
+	Encoding Symbol
+}
+
+func (err UnknownEncodingError) Error() string {
+	return fmt.Sprintf(
+		"unknown encoding: %s (supported: %s)",
+		err.Encoding,
+		strings.Join(EncodingNames(), ", "),
+	)
+}
+
+// YAMLSink writes each value as a YAML document.
+type YAMLSink struct {
+	Name string
+
+	w io.Writer
+}
+
+var _ PipeSink = (*YAMLSink)(nil)
+
+func NewYAMLSink(name string, out io.Writer) *YAMLSink {
+	return &YAMLSink{
+		Name: name,
+		w:    out,
+	}
+}
+
+func (sink *YAMLSink) String() string {
+	return sink.Name
+}
+
+func (sink *YAMLSink) Emit(val Value) error {
+	payload, err := MarshalJSON(val)
+	if err != nil {
+		return err
+	}
+
+	doc, err := yaml.JSONToYAML(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(sink.w, "---\n%s", doc)
+	return err
+}
+
+// RawSink writes each value on its own line. Strings are written verbatim;
+// all other values are encoded as JSON.
+type RawSink struct {
+	Name string
+
+	w io.Writer
+}
+
+var _ PipeSink = (*RawSink)(nil)
+
+func NewRawSink(name string, out io.Writer) *RawSink {
+	return &RawSink{
+		Name: name,
+		w:    out,
+	}
+}
+
+func (sink *RawSink) String() string {
+	return sink.Name
+}
+
+func (sink *RawSink) Emit(val Value) error {
+	str, err := rawString(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(sink.w, str)
+	return err
+}
+
+// TableSink writes each list value as a row of tab-separated columns, the
+// inverse of UnixTableProtocol. Columns are formatted as with RawSink.
+//
+// Values which are not lists are written as a single column.
+type TableSink struct {
+	Name string
+
+	w io.Writer
+}
+
+var _ PipeSink = (*TableSink)(nil)
+
+func NewTableSink(name string, out io.Writer) *TableSink {
+	return &TableSink{
+		Name: name,
+		w:    out,
+	}
+}
+
+func (sink *TableSink) String() string {
+	return sink.Name
+}
+
+func (sink *TableSink) Emit(val Value) error {
+	var list List
+	if err := val.Decode(&list); err != nil {
+		return (&RawSink{w: sink.w}).Emit(val)
+	}
+
+	vals, err := ToSlice(list)
+	if err != nil {
+		return err
+	}
+
+	cols := make([]string, len(vals))
+	for i, v := range vals {
+		cols[i], err = rawString(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(sink.w, strings.Join(cols, "\t"))
+	return err
+}
+
+func rawString(val Value) (string, error) {
+	var str string
+	if err := val.Decode(&str); err == nil {
+		return str, nil
+	}
+
+	payload, err := MarshalJSON(val)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(payload), "\n"), nil
+}
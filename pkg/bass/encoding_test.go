@@ -0,0 +1,91 @@
+package bass_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestEncodings(t *testing.T) {
+	vals := []bass.Value{
+		bass.String("hello world"),
+		bass.NewList(bass.String("a"), bass.Int(1), bass.Bindings{"b": bass.Int(2)}.Scope()),
+		bass.Bool(true),
+	}
+
+	for _, example := range []struct {
+		Encoding bass.Symbol
+		Output   string
+	}{
+		{
+			Encoding: "jsonl",
+			Output: `"hello world"
+["a",1,{"b":2}]
+true
+`,
+		},
+		{
+			Encoding: "json",
+			Output: `"hello world"
+[
+  "a",
+  1,
+  {
+    "b": 2
+  }
+]
+true
+`,
+		},
+		{
+			Encoding: "yaml",
+			Output: `---
+hello world
+---
+- a
+- 1
+- b: 2
+---
+true
+`,
+		},
+		{
+			Encoding: "raw",
+			Output: `hello world
+["a",1,{"b":2}]
+true
+`,
+		},
+		{
+			Encoding: "table",
+			Output: `hello world
+a	1	{"b":2}
+true
+`,
+		},
+	} {
+		t.Run(example.Encoding.String(), func(t *testing.T) {
+			is := is.New(t)
+
+			buf := new(bytes.Buffer)
+			sink, err := bass.NewEncodingSink(example.Encoding, "test", buf)
+			is.NoErr(err)
+			is.Equal(sink.String(), "test")
+
+			for _, val := range vals {
+				is.NoErr(sink.Emit(val))
+			}
+
+			is.Equal(buf.String(), example.Output)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		is := is.New(t)
+
+		_, err := bass.NewEncodingSink("bogus", "test", new(bytes.Buffer))
+		is.Equal(err, bass.UnknownEncodingError{Encoding: "bogus"})
+	})
+}
@@ -111,6 +111,22 @@ func TestEnums(t *testing.T) {
 						Cmd: &bass.CommandPath{"cmd"},
 					},
 				},
+				bass.Bindings{
+					"platform": bass.Bindings{
+						"os": bass.String("linux"),
+					}.Scope(),
+					"context": bass.ThunkPath{
+						Thunk: bass.Thunk{
+							Cmd: bass.ThunkCmd{
+								Cmd: &bass.CommandPath{"cmd"},
+							},
+						},
+						Path: bass.FileOrDirPath{
+							Dir: &bass.DirPath{"dir"},
+						},
+					},
+					"target": bass.String("build"),
+				}.Scope(),
 			},
 			Invalid: []bass.Value{
 				bass.String("hello"),
@@ -118,6 +134,46 @@ func TestEnums(t *testing.T) {
 				bass.Null{},
 			},
 		},
+		{
+			Enum: &bass.ImageBuildInput{},
+			Valid: []bass.Value{
+				bass.ThunkPath{
+					Thunk: bass.Thunk{
+						Cmd: bass.ThunkCmd{
+							Cmd: &bass.CommandPath{"cmd"},
+						},
+					},
+					Path: bass.FileOrDirPath{
+						Dir: &bass.DirPath{"dir"},
+					},
+				},
+				bass.HostPath{
+					ContextDir: "/some/dir",
+					Path: bass.FileOrDirPath{
+						Dir: &bass.DirPath{"dir"},
+					},
+				},
+			},
+			Invalid: []bass.Value{
+				bass.DirPath{"dir"},
+				bass.HostPath{
+					ContextDir: "/some/dir",
+					Path: bass.FileOrDirPath{
+						File: &bass.FilePath{"file"},
+					},
+				},
+				bass.ThunkPath{
+					Thunk: bass.Thunk{
+						Cmd: bass.ThunkCmd{
+							Cmd: &bass.CommandPath{"cmd"},
+						},
+					},
+					Path: bass.FileOrDirPath{
+						File: &bass.FilePath{"file"},
+					},
+				},
+			},
+		},
 	} {
 		test := test
 
@@ -11,6 +11,7 @@ import (
 	"github.com/morikuni/aec"
 	"github.com/spf13/pflag"
 	"github.com/spy16/slurp/reader"
+	"github.com/vito/bass/pkg/proto"
 )
 
 // NiceError is an error that is able to provide some extra guidance to the
@@ -240,3 +241,115 @@ type HostPathEscapeError struct {
 func (err HostPathEscapeError) Error() string {
 	return fmt.Sprintf("attempted to escape %s by opening %s", err.ContextDir, err.Attempted)
 }
+
+// StaleMemosError is returned when the results recorded in a lockfile no
+// longer match the results of calling the memoized functions.
+type StaleMemosError struct {
+	Path  string
+	Memos []StaleMemo
+}
+
+// StaleMemo is a single memoized call whose recorded result differs from its
+// actual result.
+type StaleMemo struct {
+	Module  Thunk
+	Binding Symbol
+	Input   Value
+	Locked  Value
+	Actual  Value
+}
+
+func (err StaleMemosError) Error() string {
+	noun := "memos"
+	if len(err.Memos) == 1 {
+		noun = "memo"
+	}
+
+	return fmt.Sprintf("%s: %d stale %s", err.Path, len(err.Memos), noun)
+}
+
+func (err StaleMemosError) NiceError(w io.Writer, outer error) error {
+	fmt.Fprintln(w, aec.RedF.Apply(outer.Error()))
+	WriteMemoDiff(w, err.Memos)
+	return nil
+}
+
+// WriteMemoDiff writes a human-readable diff of each memo's locked and actual
+// results, grouped by module.
+func WriteMemoDiff(w io.Writer, memos []StaleMemo) {
+	var lastModule Thunk
+	for i, memo := range memos {
+		if i == 0 || !memo.Module.Equal(lastModule) {
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "module %s:\n", memo.Module)
+			lastModule = memo.Module
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  %s\n", Pair{A: memo.Binding, D: memo.Input})
+		fmt.Fprintln(w, aec.RedF.Apply(fmt.Sprintf("  - %s", memo.Locked)))
+		fmt.Fprintln(w, aec.GreenF.Apply(fmt.Sprintf("  + %s", memo.Actual)))
+	}
+}
+
+// MemoConflictsError is returned when merging lockfiles which changed the
+// same memoized results in different ways.
+type MemoConflictsError struct {
+	Path      string
+	Conflicts []MemoConflict
+}
+
+func (err MemoConflictsError) Error() string {
+	noun := "conflicts"
+	if len(err.Conflicts) == 1 {
+		noun = "conflict"
+	}
+
+	return fmt.Sprintf("%s: %d %s", err.Path, len(err.Conflicts), noun)
+}
+
+func (conflicts MemoConflictsError) NiceError(w io.Writer, outer error) error {
+	fmt.Fprintln(w, aec.RedF.Apply(outer.Error()))
+
+	for _, conflict := range conflicts.Conflicts {
+		var module Thunk
+		if err := module.UnmarshalProto(conflict.Module); err != nil {
+			return err
+		}
+
+		input, err := FromProto(conflict.Input)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "module %s:\n", module)
+		fmt.Fprintf(w, "  %s\n", Pair{A: Symbol(conflict.Binding), D: input})
+
+		for _, side := range []struct {
+			name string
+			val  *proto.Value
+		}{
+			{"base", conflict.Base},
+			{"ours", conflict.Ours},
+			{"theirs", conflict.Theirs},
+		} {
+			desc := aec.Faint.Apply("(absent)")
+			if side.val != nil {
+				val, err := FromProto(side.val)
+				if err != nil {
+					return err
+				}
+
+				desc = val.String()
+			}
+
+			fmt.Fprintf(w, "    %-8s%s\n", side.name+":", desc)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "conflicting results have been resolved in favor of ours")
+
+	return nil
+}
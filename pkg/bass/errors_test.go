@@ -85,3 +85,50 @@ func TestUnboundErrorNice(t *testing.T) {
 		})
 	}
 }
+
+func TestStaleMemosErrorNice(t *testing.T) {
+	is := is.New(t)
+
+	module := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"strings"},
+		},
+	}
+
+	staleErr := bass.StaleMemosError{
+		Path: "bass.lock",
+		Memos: []bass.StaleMemo{
+			{
+				Module:  module,
+				Binding: "upper-case",
+				Input:   bass.NewList(bass.String("hello")),
+				Locked:  bass.String("hello"),
+				Actual:  bass.String("HELLO"),
+			},
+			{
+				Module:  module,
+				Binding: "lower-case",
+				Input:   bass.NewList(bass.String("HELLO")),
+				Locked:  bass.String("HELLO"),
+				Actual:  bass.String("hello"),
+			},
+		},
+	}
+
+	is.Equal(staleErr.Error(), "bass.lock: 2 stale memos")
+
+	out := new(bytes.Buffer)
+	is.NoErr(staleErr.NiceError(out, staleErr))
+
+	is.Equal(out.String(), aec.RedF.Apply("bass.lock: 2 stale memos")+"\n"+
+		"\n"+
+		"module "+module.String()+":\n"+
+		"\n"+
+		"  (upper-case \"hello\")\n"+
+		aec.RedF.Apply(`  - "hello"`)+"\n"+
+		aec.GreenF.Apply(`  + "HELLO"`)+"\n"+
+		"\n"+
+		"  (lower-case \"HELLO\")\n"+
+		aec.RedF.Apply(`  - "HELLO"`)+"\n"+
+		aec.GreenF.Apply(`  + "hello"`)+"\n")
+}
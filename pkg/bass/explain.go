@@ -0,0 +1,571 @@
+package bass
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/morikuni/aec"
+	"github.com/vito/bass/pkg/ioctx"
+	"github.com/vito/bass/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+// ThunkFingerprint is a flattened representation of everything that
+// contributes to a thunk's cache key, mapping each field to a readable value.
+//
+// Fields of the thunk's parent image are prefixed with "image.".
+type ThunkFingerprint map[string]string
+
+// FingerprintChange is a field which differs between two fingerprints.
+type FingerprintChange struct {
+	Field string
+
+	// Old is the previous value, or empty if the field was added.
+	Old string
+
+	// New is the current value, or empty if the field was removed.
+	New string
+}
+
+// Fingerprint computes the fingerprint of the thunk.
+//
+// Host paths are fingerprinted by the digest of their content, since their
+// content is what ends up in the cache key. Thunks embedded in values, e.g.
+// thunk paths in args or mounts, are fingerprinted recursively.
+func Fingerprint(thunk Thunk) (ThunkFingerprint, error) {
+	return fingerprint(thunk, nil)
+}
+
+func fingerprint(thunk Thunk, digests hostDigests) (ThunkFingerprint, error) {
+	fp := ThunkFingerprint{}
+	err := fp.add("", thunk, digests)
+	if err != nil {
+		return nil, err
+	}
+
+	return fp, nil
+}
+
+func (fp ThunkFingerprint) add(prefix string, thunk Thunk, digests hostDigests) error {
+	set := func(field string, val Value) error {
+		return fp.addValue(prefix+field, val, digests)
+	}
+
+	if thunk.Image != nil {
+		if thunk.Image.Thunk != nil {
+			err := fp.add(prefix+"image.", *thunk.Image.Thunk, digests)
+			if err != nil {
+				return err
+			}
+		} else {
+			err := set("image", thunk.Image.ToValue())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if thunk.Insecure {
+		fp[prefix+"insecure"] = "true"
+	}
+
+	if thunk.ImageEnv {
+		fp[prefix+"image_env"] = "true"
+	}
+
+	if thunk.User != "" {
+		fp[prefix+"user"] = thunk.User
+	}
+
+	if thunk.SSH {
+		fp[prefix+"ssh"] = "true"
+	}
+
+	if thunk.Network != "" {
+		fp[prefix+"network"] = thunk.Network
+	}
+
+	if len(thunk.Capabilities) > 0 {
+		fp[prefix+"capabilities"] = strings.Join(thunk.Capabilities, ",")
+	}
+
+	if len(thunk.Devices) > 0 {
+		fp[prefix+"devices"] = strings.Join(thunk.Devices, ",")
+	}
+
+	err := set("cmd", thunk.Cmd.ToValue())
+	if err != nil {
+		return err
+	}
+
+	for i, arg := range thunk.Args {
+		err := set(fmt.Sprintf("args[%d]", i), arg)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, val := range thunk.Stdin {
+		err := set(fmt.Sprintf("stdin[%d]", i), val)
+		if err != nil {
+			return err
+		}
+	}
+
+	if thunk.Env != nil {
+		err := thunk.Env.Each(func(name Symbol, val Value) error {
+			return set("env."+name.String(), val)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if thunk.Dir != nil {
+		err := set("dir", thunk.Dir.ToValue())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, mount := range thunk.Mounts {
+		err := set(fmt.Sprintf("mounts[%s]", mount.Target.Slash()), mount.Source.ToValue())
+		if err != nil {
+			return err
+		}
+
+		if mount.Owner != "" {
+			fp[prefix+fmt.Sprintf("mounts[%s].owner", mount.Target.Slash())] = mount.Owner
+		}
+	}
+
+	if thunk.Labels != nil {
+		err := thunk.Labels.Each(func(name Symbol, val Value) error {
+			return set("labels."+name.String(), val)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if thunk.Limits != nil {
+		limits, err := ValueOf(*thunk.Limits)
+		if err != nil {
+			return err
+		}
+
+		err = set("limits", limits)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addValue adds the value to the fingerprint, recursing into thunks, thunk
+// paths, lists, and scopes so that a change deep within them is reported by
+// the field that changed rather than an opaque hash.
+func (fp ThunkFingerprint) addValue(field string, val Value, digests hostDigests) error {
+	switch x := val.(type) {
+	case ThunkPath:
+		fp[field+".path"] = x.Path.Slash()
+		return fp.add(field+".", x.Thunk, digests)
+	case Thunk:
+		return fp.add(field+".", x, digests)
+	case HostPath:
+		digest, err := hostPathDigest(x, digests)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+
+		fp[field] = fmt.Sprintf("%s (sha256:%s)", x, digest)
+		return nil
+	case *Scope:
+		return x.Each(func(name Symbol, val Value) error {
+			return fp.addValue(field+"."+name.String(), val, digests)
+		})
+	case Pair:
+		vals, err := ToSlice(x)
+		if err != nil {
+			return err
+		}
+
+		for i, val := range vals {
+			err := fp.addValue(fmt.Sprintf("%s[%d]", field, i), val, digests)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		fp[field] = val.String()
+		return nil
+	}
+}
+
+// hostDigests caches the digest of each host file by its absolute path, so
+// that unchanged files do not have to be read again.
+type hostDigests map[string]hostDigest
+
+// hostDigest is the digest of a host file, which is valid so long as its size
+// and modification time have not changed.
+type hostDigest struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Digest  string    `json:"digest"`
+}
+
+// hostPathDigest returns the digest of the content of the host path. For
+// directories, it includes the path and content of each file.
+//
+// File digests are cached in digests, if non-nil.
+func hostPathDigest(host HostPath, digests hostDigests) (string, error) {
+	root, err := host.checkEscape()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		digest, err := hostFileDigest(path, d, digests)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(hash, "%s\x00%s\n", filepath.ToSlash(rel), digest)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hostFileDigest(path string, d fs.DirEntry, digests hostDigests) (string, error) {
+	info, err := d.Info()
+	if err != nil {
+		return "", err
+	}
+
+	cached, found := digests[path]
+	if found && cached.Size == info.Size() && cached.ModTime.Equal(info.ModTime()) {
+		return cached.Digest, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	if digests != nil {
+		digests[path] = hostDigest{
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			Digest:  digest,
+		}
+	}
+
+	return digest, nil
+}
+
+// Diff returns the fields which differ from the previous fingerprint, sorted
+// by field.
+func (fp ThunkFingerprint) Diff(prev ThunkFingerprint) []FingerprintChange {
+	var changes []FingerprintChange
+	for field, val := range fp {
+		if prev[field] != val {
+			changes = append(changes, FingerprintChange{
+				Field: field,
+				Old:   prev[field],
+				New:   val,
+			})
+		}
+	}
+
+	for field, val := range prev {
+		if _, found := fp[field]; !found {
+			changes = append(changes, FingerprintChange{
+				Field: field,
+				Old:   val,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+// CacheExplainer persists the fingerprint of each thunk that is run, so that
+// when a thunk misses the cache it can explain what changed.
+type CacheExplainer struct {
+	// Dir is where fingerprints are stored.
+	Dir string
+
+	// Limit is the number of fingerprints kept for each command.
+	Limit int
+
+	// digests caches host file digests, loaded from Dir on first use.
+	digests hostDigests
+
+	lock sync.Mutex
+}
+
+// NewCacheExplainer returns a CacheExplainer which stores fingerprints in the
+// given directory.
+func NewCacheExplainer(dir string) *CacheExplainer {
+	return &CacheExplainer{
+		Dir:   dir,
+		Limit: 16,
+	}
+}
+
+type cacheExplainerKey struct{}
+
+// WithCacheExplainer configures the context so that each thunk that is run
+// is explained by the explainer.
+func WithCacheExplainer(ctx context.Context, explainer *CacheExplainer) context.Context {
+	return context.WithValue(ctx, cacheExplainerKey{}, explainer)
+}
+
+// ExplainingCache returns true if the context has a CacheExplainer, i.e. if
+// runtimes should report whether each thunk was cached.
+func ExplainingCache(ctx context.Context) bool {
+	return cacheExplainerFromContext(ctx) != nil
+}
+
+func cacheExplainerFromContext(ctx context.Context) *CacheExplainer {
+	explainer := ctx.Value(cacheExplainerKey{})
+	if explainer != nil {
+		return explainer.(*CacheExplainer)
+	}
+
+	return nil
+}
+
+// Explain records the fingerprint of a thunk which missed the cache and
+// returns the fields that changed since the most similar fingerprint recorded
+// for the same command.
+//
+// The returned bool is false if the command has never been recorded before.
+// If it is true but there are no changes, the thunk has been run before but
+// its result is no longer cached, e.g. because it was pruned.
+func (explainer *CacheExplainer) Explain(thunk Thunk) ([]FingerprintChange, bool, error) {
+	return explainer.record(thunk)
+}
+
+// Record records the fingerprint of a thunk which hit the cache, so that
+// it may be compared against by a future Explain.
+func (explainer *CacheExplainer) Record(thunk Thunk) error {
+	_, _, err := explainer.record(thunk)
+	return err
+}
+
+func (explainer *CacheExplainer) record(thunk Thunk) ([]FingerprintChange, bool, error) {
+	explainer.lock.Lock()
+	defer explainer.lock.Unlock()
+
+	err := explainer.loadDigests()
+	if err != nil {
+		return nil, false, err
+	}
+
+	fp, err := fingerprint(thunk, explainer.digests)
+	if err != nil {
+		return nil, false, err
+	}
+
+	path := filepath.Join(explainer.Dir, fingerprintKey(thunk)+".json")
+
+	var history []ThunkFingerprint
+	err = readJSONFile(path, &history)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var closest []FingerprintChange
+	var closestIdx = -1
+	for i, prev := range history {
+		changes := fp.Diff(prev)
+		if closestIdx == -1 || len(changes) < len(closest) {
+			closest = changes
+			closestIdx = i
+		}
+	}
+
+	if closestIdx != -1 && len(closest) == 0 {
+		// seen before; move it to the front
+		history = append(history[:closestIdx], history[closestIdx+1:]...)
+	}
+
+	history = append([]ThunkFingerprint{fp}, history...)
+	if explainer.Limit > 0 && len(history) > explainer.Limit {
+		history = history[:explainer.Limit]
+	}
+
+	err = explainer.writeJSONFile(path, history)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = explainer.writeJSONFile(explainer.digestsPath(), explainer.digests)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return closest, closestIdx != -1, nil
+}
+
+func (explainer *CacheExplainer) digestsPath() string {
+	return filepath.Join(explainer.Dir, "host-digests.json")
+}
+
+func (explainer *CacheExplainer) loadDigests() error {
+	if explainer.digests != nil {
+		return nil
+	}
+
+	explainer.digests = hostDigests{}
+	return readJSONFile(explainer.digestsPath(), &explainer.digests)
+}
+
+// readJSONFile decodes the file into dest, leaving it as-is if the file does
+// not exist or is corrupt.
+func readJSONFile(path string, dest any) error {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	// start over rather than getting stuck on a corrupt file
+	_ = json.Unmarshal(payload, dest)
+
+	return nil
+}
+
+func (explainer *CacheExplainer) writeJSONFile(path string, val any) error {
+	payload, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(explainer.Dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, bytes.NewReader(payload))
+}
+
+// fingerprintKey identifies thunks whose fingerprints are compared with each
+// other: those which run the same command in the same platform.
+func fingerprintKey(thunk Thunk) string {
+	key := thunk.Cmd.ToValue().String()
+	if platform := thunk.Platform(); platform != nil {
+		key += " " + platform.String()
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExplainCache is called by runtimes for each thunk they run, indicating
+// whether its result came from the cache.
+//
+// If a CacheExplainer is configured, the thunk's fingerprint is recorded and
+// an explanation of each cache miss is written to stderr.
+func ExplainCache(ctx context.Context, thunk Thunk, cached bool) {
+	explainer := cacheExplainerFromContext(ctx)
+	if explainer == nil {
+		return
+	}
+
+	if cached {
+		err := explainer.Record(thunk)
+		if err != nil {
+			zapctx.FromContext(ctx).Warn("failed to record cache hit", zap.Error(err))
+		}
+
+		return
+	}
+
+	changes, seen, err := explainer.Explain(thunk)
+	if err != nil {
+		zapctx.FromContext(ctx).Warn("failed to explain cache", zap.Error(err))
+		return
+	}
+
+	WriteCacheMiss(ioctx.StderrFromContext(ctx), thunk, changes, seen)
+}
+
+// WriteCacheMiss writes an explanation of a thunk's cache miss.
+func WriteCacheMiss(w io.Writer, thunk Thunk, changes []FingerprintChange, seen bool) {
+	fmt.Fprintf(w, "%s %s\n", aec.YellowF.Apply("cache miss:"), thunk)
+
+	if !seen {
+		fmt.Fprintln(w, "  first run")
+		return
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "  unchanged; its cached result was pruned or evicted")
+		return
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Old == "":
+			fmt.Fprintf(w, "  %s: %s\n", change.Field, aec.GreenF.Apply("+ "+change.New))
+		case change.New == "":
+			fmt.Fprintf(w, "  %s: %s\n", change.Field, aec.RedF.Apply("- "+change.Old))
+		default:
+			fmt.Fprintf(w, "  %s:\n", change.Field)
+			fmt.Fprintf(w, "    %s\n", aec.RedF.Apply("- "+change.Old))
+			fmt.Fprintf(w, "    %s\n", aec.GreenF.Apply("+ "+change.New))
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package bass_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestFingerprint(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "file"), []byte("hello"), 0644))
+
+	src := bass.NewHostPath(dir, bass.ParseFileOrDirPath("./"))
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Thunk: &bass.Thunk{
+				Image: &bass.ThunkImage{
+					Ref: &bass.ThunkImageRef{
+						Platform:   bass.LinuxPlatform,
+						Repository: "alpine",
+						Tag:        "latest",
+					},
+				},
+				Cmd:  bass.ThunkCmd{Cmd: &bass.CommandPath{"apk"}},
+				Args: []bass.Value{bass.String("add"), bass.String("git")},
+			},
+		},
+		Cmd:  bass.ThunkCmd{Cmd: &bass.CommandPath{"git"}},
+		Args: []bass.Value{bass.String("clone")},
+		Env:  bass.Bindings{"FOO": bass.String("bar")}.Scope(),
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{HostPath: &src},
+				Target: bass.ParseFileOrDirPath("./src/"),
+			},
+		},
+		Labels: bass.Bindings{"at": bass.Int(1)}.Scope(),
+	}
+
+	fp, err := bass.Fingerprint(thunk)
+	is.NoErr(err)
+
+	is.Equal(fp["cmd"], `.git`)
+	is.Equal(fp["args[0]"], `"clone"`)
+	is.Equal(fp["env.FOO"], `"bar"`)
+	is.Equal(fp["labels.at"], `1`)
+	is.Equal(fp["image.cmd"], `.apk`)
+	is.Equal(fp["image.args[1]"], `"git"`)
+	is.Equal(fp["image.image.repository"], `"alpine"`)
+
+	// content changes are detected
+	is.NoErr(os.WriteFile(filepath.Join(dir, "file"), []byte("goodbye"), 0644))
+
+	changed := thunk
+	changed.Labels = bass.Bindings{"at": bass.Int(2)}.Scope()
+	changed.Env = nil
+
+	newFp, err := bass.Fingerprint(changed)
+	is.NoErr(err)
+
+	changes := newFp.Diff(fp)
+	is.Equal(len(changes), 3)
+	is.Equal(changes[0], bass.FingerprintChange{Field: "env.FOO", Old: `"bar"`})
+	is.Equal(changes[1], bass.FingerprintChange{Field: "labels.at", Old: `1`, New: `2`})
+	is.Equal(changes[2].Field, "mounts[./src/]")
+}
+
+func TestFingerprintThunkPaths(t *testing.T) {
+	is := is.New(t)
+
+	build := bass.Thunk{
+		Cmd:  bass.ThunkCmd{Cmd: &bass.CommandPath{"go"}},
+		Args: []bass.Value{bass.String("build")},
+	}
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"run"}},
+		Args: []bass.Value{
+			bass.ThunkPath{
+				Thunk: build,
+				Path:  bass.ParseFileOrDirPath("./out/"),
+			},
+		},
+	}
+
+	fp, err := bass.Fingerprint(thunk)
+	is.NoErr(err)
+	is.Equal(fp["args[0].path"], `./out/`)
+	is.Equal(fp["args[0].cmd"], `.go`)
+	is.Equal(fp["args[0].args[0]"], `"build"`)
+
+	changed := thunk.WithArgs([]bass.Value{
+		bass.ThunkPath{
+			Thunk: build.WithArgs([]bass.Value{bass.String("test")}),
+			Path:  bass.ParseFileOrDirPath("./out/"),
+		},
+	})
+
+	newFp, err := bass.Fingerprint(changed)
+	is.NoErr(err)
+	is.Equal(newFp.Diff(fp), []bass.FingerprintChange{
+		{Field: "args[0].args[0]", Old: `"build"`, New: `"test"`},
+	})
+}
+
+func TestCacheExplainer(t *testing.T) {
+	is := is.New(t)
+
+	explainer := bass.NewCacheExplainer(t.TempDir())
+
+	thunk := bass.Thunk{
+		Cmd:  bass.ThunkCmd{Cmd: &bass.CommandPath{"echo"}},
+		Args: []bass.Value{bass.String("hello")},
+	}
+
+	// first run
+	changes, seen, err := explainer.Explain(thunk)
+	is.NoErr(err)
+	is.True(!seen)
+	is.Equal(len(changes), 0)
+
+	// same thunk, e.g. after pruning
+	changes, seen, err = explainer.Explain(thunk)
+	is.NoErr(err)
+	is.True(seen)
+	is.Equal(len(changes), 0)
+
+	other := thunk.WithArgs([]bass.Value{bass.String("goodbye")})
+
+	changes, seen, err = explainer.Explain(other)
+	is.NoErr(err)
+	is.True(seen)
+	is.Equal(changes, []bass.FingerprintChange{
+		{Field: "args[0]", Old: `"hello"`, New: `"goodbye"`},
+	})
+
+	// cache hits are recorded too
+	hit := thunk.WithLabel("hit", bass.Bool(true))
+	is.NoErr(explainer.Record(hit))
+
+	// compared to the closest previous run
+	closest := hit.WithLabel("foo", bass.String("bar"))
+
+	changes, seen, err = explainer.Explain(closest)
+	is.NoErr(err)
+	is.True(seen)
+	is.Equal(changes, []bass.FingerprintChange{
+		{Field: "labels.foo", New: `"bar"`},
+	})
+
+	buf := new(bytes.Buffer)
+	bass.WriteCacheMiss(buf, closest, changes, seen)
+	is.True(bytes.Contains(buf.Bytes(), []byte("labels.foo")))
+
+	buf.Reset()
+	bass.WriteCacheMiss(buf, closest, nil, true)
+	is.True(bytes.Contains(buf.Bytes(), []byte("pruned")))
+
+	buf.Reset()
+	bass.WriteCacheMiss(buf, closest, nil, false)
+	is.True(bytes.Contains(buf.Bytes(), []byte("first run")))
+}
+
+func TestCacheExplainerHostDigests(t *testing.T) {
+	is := is.New(t)
+
+	explainerDir := t.TempDir()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	is.NoErr(os.WriteFile(file, []byte("hello"), 0644))
+
+	src := bass.NewHostPath(dir, bass.ParseFileOrDirPath("./"))
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"cat"}},
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{HostPath: &src},
+				Target: bass.ParseFileOrDirPath("./src/"),
+			},
+		},
+	}
+
+	is.NoErr(bass.NewCacheExplainer(explainerDir).Record(thunk))
+
+	// content of the same size and modification time is not read again
+	info, err := os.Stat(file)
+	is.NoErr(err)
+	is.NoErr(os.WriteFile(file, []byte("olleh"), 0644))
+	is.NoErr(os.Chtimes(file, info.ModTime(), info.ModTime()))
+
+	changes, seen, err := bass.NewCacheExplainer(explainerDir).Explain(thunk)
+	is.NoErr(err)
+	is.True(seen)
+	is.Equal(len(changes), 0)
+
+	// changes to the size or modification time are detected
+	is.NoErr(os.WriteFile(file, []byte("goodbye"), 0644))
+
+	changes, seen, err = bass.NewCacheExplainer(explainerDir).Explain(thunk)
+	is.NoErr(err)
+	is.True(seen)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Field, "mounts[./src/]")
+}
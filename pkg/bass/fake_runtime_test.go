@@ -3,16 +3,23 @@ package bass_test
 import (
 	"archive/tar"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
+	"path"
 	"testing/fstest"
 
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/vito/bass/pkg/bass"
 )
 
 type FakeRuntime struct {
+	Arch string
+
 	ExportPaths []ExportPath
+	Published   []bass.Thunk
 }
 
 type ExportPath struct {
@@ -36,8 +43,99 @@ func (fake *FakeRuntime) Load(context.Context, bass.Thunk) (*bass.Scope, error)
 	return nil, fmt.Errorf("Load unimplemented")
 }
 
-func (fake *FakeRuntime) Export(context.Context, io.Writer, bass.Thunk) error {
-	return fmt.Errorf("Export unimplemented")
+// Export writes a minimal OCI layout whose only layer contains the thunk's
+// SHA256 and whose config has the runtime's architecture.
+func (fake *FakeRuntime) Export(_ context.Context, w io.Writer, thunk bass.Thunk) error {
+	sha2, err := thunk.SHA256()
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+
+	writeBlob := func(mediaType string, content []byte) (ocispecs.Descriptor, error) {
+		dig := digest.FromBytes(content)
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join("blobs", dig.Algorithm().String(), dig.Encoded()),
+			Mode:     0644,
+			Size:     int64(len(content)),
+		})
+		if err != nil {
+			return ocispecs.Descriptor{}, err
+		}
+
+		_, err = tw.Write(content)
+		if err != nil {
+			return ocispecs.Descriptor{}, err
+		}
+
+		return ocispecs.Descriptor{
+			MediaType: mediaType,
+			Digest:    dig,
+			Size:      int64(len(content)),
+		}, nil
+	}
+
+	layer, err := writeBlob(ocispecs.MediaTypeImageLayer, []byte(sha2))
+	if err != nil {
+		return err
+	}
+
+	config, err := json.Marshal(ocispecs.Image{
+		OS:           "linux",
+		Architecture: fake.Arch,
+	})
+	if err != nil {
+		return err
+	}
+
+	configDesc, err := writeBlob(ocispecs.MediaTypeImageConfig, config)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := json.Marshal(ocispecs.Manifest{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers:    []ocispecs.Descriptor{layer},
+	})
+	if err != nil {
+		return err
+	}
+
+	manifestDesc, err := writeBlob(ocispecs.MediaTypeImageManifest, manifest)
+	if err != nil {
+		return err
+	}
+
+	manifestDesc.Annotations = map[string]string{
+		ocispecs.AnnotationRefName: "fake",
+	}
+
+	index, err := json.Marshal(ocispecs.Index{
+		Manifests: []ocispecs.Descriptor{manifestDesc},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "index.json",
+		Mode:     0644,
+		Size:     int64(len(index)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tw.Write(index)
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
 }
 
 func (fake *FakeRuntime) SetExportPath(path bass.ThunkPath, fs fstest.MapFS) {
@@ -93,10 +191,26 @@ func (fake *FakeRuntime) ExportPath(ctx context.Context, w io.Writer, path bass.
 	return fmt.Errorf("thunk path not faked out: %s", path)
 }
 
+func (fake *FakeRuntime) Publish(_ context.Context, ref bass.ThunkImageRef, thunk bass.Thunk) (bass.ThunkImageRef, error) {
+	fake.Published = append(fake.Published, thunk)
+
+	sha2, err := thunk.SHA256()
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	ref.Digest = digest.FromString(sha2).String()
+	return ref, nil
+}
+
 func (fake *FakeRuntime) Prune(context.Context, bass.PruneOpts) error {
 	return fmt.Errorf("Prune unimplemented")
 }
 
+func (fake *FakeRuntime) DiskUsage(context.Context) ([]bass.DiskUsage, error) {
+	return nil, fmt.Errorf("DiskUsage unimplemented")
+}
+
 func (fake *FakeRuntime) Close() error {
 	return nil
 }
@@ -685,16 +685,127 @@ func init() {
 		`=> (with-insecure (.boom) true)`,
 		`=> (= (.boom) (with-insecure (.boom) false))`)
 
+	Ground.Set("with-image-env",
+		Func("with-image-env", "[thunk bool]", (Thunk).WithImageEnv),
+		`returns thunk with the image env flag set to bool`,
+		`The image env flag determines whether the thunk runs as its OCI image is configured to run commands: as the image's user, through the image's entrypoint.`,
+		`The image's env vars are inherited either way. Runtimes may be configured to set this flag by default.`,
+		`The image's working directory is not used, since the command must run in the thunk's working directory for thunk paths and outputs to work. Use (with-dir) with an absolute path instead.`,
+		`=> (with-image-env ($ go version) true)`)
+
+	Ground.Set("with-user",
+		Func("with-user", "[thunk user]", (Thunk).WithUser),
+		`returns thunk configured to run as the given user`,
+		`The user is in the form user[:group], where each may be a name or a numeric ID. Files written by the thunk are owned by the user.`,
+		`=> (with-user ($ npm install) "1000:1000")`)
+
+	Ground.Set("with-ssh",
+		Func("with-ssh", "[thunk]", (Thunk).WithSSH),
+		`returns thunk with the host's SSH agent forwarded to it`,
+		`The agent's socket is set as $SSH_AUTH_SOCK, so tools like git can use the host's keys without them being written anywhere. The socket path and keys do not affect caching.`,
+		`=> (with-ssh ($ git clone git@github.com:vito/bass))`)
+
+	Ground.Set("with-limits",
+		Func("with-limits", "[thunk limits]", (Thunk).WithLimits),
+		`returns thunk with resource limits`,
+		`Limits may specify the :memory and :tmpfs-size as strings like "4GB", along with the number of :cpus and the maximum number of :pids.`,
+		`The :tmpfs-size applies to the /tmp and /dev/shm mounts. The other limits are enforced using cgroups on a best-effort basis; if they can't be enforced, a warning is printed and the command runs without them. The Buildkit runtime can only enforce them for insecure thunks.`,
+		`=> (with-limits ($ go test ./...) {:memory "4GB" :cpus 2 :pids 512 :tmpfs-size "1GB"})`)
+
+	Ground.Set("with-network",
+		Func("with-network", "[thunk mode]", (Thunk).WithNetwork),
+		`returns thunk with the network mode set to mode`,
+		`The mode may be :none to run with no network access, :host to run in the host's network, or :default to run with the runtime's default network access.`,
+		`Runtimes may be configured to disable network access for thunks whose inputs are all pinned by digest, unless a mode is set.`,
+		`=> (with-network ($ go test ./...) :none)`)
+
+	Ground.Set("with-capabilities",
+		Func("with-capabilities", "[thunk caps]", func(ctx context.Context, thunk Thunk, caps []Symbol) (Thunk, error) {
+			privileges := PrivilegesFromContext(ctx)
+
+			names := make([]string, len(caps))
+			for i, c := range caps {
+				if err := privileges.CheckCapability(c.String()); err != nil {
+					return Thunk{}, err
+				}
+
+				names[i] = c.String()
+			}
+
+			return thunk.WithCapabilities(names...)
+		}),
+		`returns thunk with additional Linux capabilities granted`,
+		`Capabilities are named without the CAP_ prefix, e.g. :net-admin for CAP_NET_ADMIN. Unlike (with-insecure), the thunk gains no other privileges.`,
+		`Each capability must be allowed by privileges.capabilities in the Bass config.`,
+		`Not every runtime can grant individual capabilities; the Buildkit runtime rejects them unless the thunk is also insecure.`,
+		`=> (with-capabilities ($ ip link add dummy0 type dummy) [:net-admin])`)
+
+	Ground.Set("with-device",
+		Func("with-device", "[thunk path]", func(ctx context.Context, thunk Thunk, dev string) (Thunk, error) {
+			if err := PrivilegesFromContext(ctx).CheckDevice(dev); err != nil {
+				return Thunk{}, err
+			}
+
+			return thunk.WithDevice(dev)
+		}),
+		`returns thunk with access to the host device at path`,
+		`The device must be allowed by privileges.devices in the Bass config.`,
+		`Not every runtime can expose individual devices; the Buildkit runtime rejects them unless the thunk is also insecure.`,
+		`=> (with-device ($ sshfs host: ./mnt/) "/dev/fuse")`)
+
 	Ground.Set("with-label",
 		Func("with-label", "[thunk name val]", (Thunk).WithLabel),
 		`returns thunk with the label set to val`,
 		`Labels are typically used to control caching. Two thunks that differ only in labels will evaluate separately and produce independent results.`,
 		`=> (with-label ($ sleep 10) :at (now 10))`)
 
+	Ground.Set("with-image-config",
+		Func("with-image-config", "[thunk config]", (Thunk).WithImageConfig),
+		`returns thunk with the OCI image config to use when it is exported`,
+		`The config may specify an :entrypoint and default :cmd, :env vars to set in addition to those of the base image, a :user to run as, :labels, and :exposed-ports.`,
+		`Only the config of the exported thunk is used, not those of thunks in its image chain.`,
+		`=> (with-image-config ($ go build -o /usr/bin/app ./) {:entrypoint ["/usr/bin/app"] :user "nobody" :exposed-ports ["8080/tcp"]})`)
+
 	Ground.Set("with-mount",
-		Func("with-mount", "[thunk source target]", (Thunk).WithMount),
+		Func("with-mount", "[thunk source target & opts]", func(thunk Thunk, src ThunkMountSource, tgt FileOrDirPath, opts ...*Scope) (Thunk, error) {
+			if len(opts) > 1 {
+				return Thunk{}, ArityError{
+					Name: "with-mount",
+					Need: 4,
+					Have: len(opts) + 3,
+				}
+			}
+
+			mount := ThunkMount{
+				Source: src,
+				Target: tgt,
+			}
+
+			if len(opts) == 1 {
+				var config struct {
+					Owner string `json:"owner,omitempty"`
+				}
+				if err := opts[0].Decode(&config); err != nil {
+					return Thunk{}, err
+				}
+
+				mount.Owner = config.Owner
+
+				if mount.Owner != "" {
+					if _, _, err := ParseOwner(mount.Owner); err != nil {
+						return Thunk{}, err
+					}
+				}
+			}
+
+			thunk.Mounts = append(thunk.Mounts, mount)
+
+			return thunk, nil
+		}),
 		`returns thunk with a mount from source to the target path`,
-		`=> (with-mount ($ find ./inputs/) *dir*/inputs/ ./inputs/)`)
+		`Opts may specify an :owner for the mounted content as a numeric uid[:gid], e.g. "1000:1000". The gid defaults to the uid.`,
+		`=> (with-mount ($ find ./inputs/) *dir*/inputs/ ./inputs/)`,
+		`=> (with-mount ($ find ./inputs/) *dir*/inputs/ ./inputs/ {:owner "1000"})`)
 
 	Ground.Set("thunk-cmd",
 		Func("thunk-cmd", "[thunk]", func(thunk Thunk) Value {
@@ -731,6 +842,63 @@ func init() {
 		`resolve an image reference to its most exact form`,
 		`=> (resolve {:platform {:os "linux"} :repository "golang" :tag "latest"})`)
 
+	Ground.Set("dockerfile",
+		Func("dockerfile", "[context-dir & opts]", func(dir ImageBuildInput, opts ...*Scope) (ThunkDockerBuild, error) {
+			build := ThunkDockerBuild{
+				Platform: LinuxPlatform,
+				Context:  dir,
+			}
+
+			if len(opts) > 1 {
+				return ThunkDockerBuild{}, ArityError{
+					Name: "dockerfile",
+					Need: 2,
+					Have: len(opts) + 1,
+				}
+			}
+
+			if len(opts) == 1 {
+				var config struct {
+					Platform *Platform `json:"platform,omitempty"`
+					File     FilePath  `json:"file,omitempty"`
+					Target   string    `json:"target,omitempty"`
+					Args     *Scope    `json:"args,omitempty"`
+				}
+				if err := opts[0].Decode(&config); err != nil {
+					return ThunkDockerBuild{}, err
+				}
+
+				if config.Platform != nil {
+					build.Platform = *config.Platform
+				}
+
+				build.Dockerfile = config.File
+				build.Target = config.Target
+				build.Args = config.Args
+			}
+
+			return build, nil
+		}),
+		`returns an image built from a Dockerfile in the context directory`,
+		`The image may be passed to (from) or (with-image) like any other image.`,
+		`Opts may specify a :file path to the Dockerfile relative to the context directory, a :target stage to build, :args for ARG instructions, and a :platform to build for, which defaults to Linux.`,
+		`=> (dockerfile *dir*/)`,
+		`=> (from (dockerfile *dir*/ {:file ./Dockerfile :target "build" :args {:VERSION "1.0"}}) ($ go version))`)
+
+	Ground.Set("publish",
+		Func("publish", "[thunk ref]", (Thunk).Publish),
+		`pushes the thunk to a registry as an image with the given reference`,
+		`Returns the image reference with the digest that was pushed, which may be passed to (from) or (with-image).`,
+		`The image is configured by the thunk's (with-image-config), if any.`,
+		`=> (publish (from (linux/alpine) ($ echo hello)) "registry.example.com/app:latest")`)
+
+	Ground.Set("export-multi",
+		Func("export-multi", "[platform-thunks]", NewImageIndex),
+		`returns a multi-platform image composed of a thunk for each platform`,
+		`Takes a scope mapping platforms, in the form os/arch, to thunks. Each thunk is run on the runtime that matches its platform, in parallel.`,
+		`Emit the result and pipe it to bass --export to write an OCI image index tarball containing every image.`,
+		`=> (export-multi {:linux/amd64 (from (linux/alpine) ($ echo hello)) :linux/arm64 (from (linux/alpine) ($ echo hello))})`)
+
 	Ground.Set("start",
 		Func("start", "[thunk handler]", func(ctx context.Context, thunk Thunk, handler Combiner) (Combiner, error) {
 			return thunk.Start(ctx, handler)
@@ -766,6 +934,24 @@ func init() {
 		`=> (def file-thunk (from (linux/alpine) ($ sh -c "echo 42 > file")))`,
 		`=> (next (read file-thunk/file :json))`,
 	)
+
+	Ground.Set("defprotocol",
+		Func("defprotocol", "[name decoder]", func(name Symbol, decoder Combiner) (Symbol, error) {
+			err := RegisterProtocol(name, CombinerProtocol{decoder})
+			if err != nil {
+				return "", err
+			}
+
+			return name, nil
+		}),
+		`registers a protocol for use with (read)`,
+		`The decoder is called with a source yielding the raw content as a single string and a sink to emit decoded values to.`,
+		`Protocols are registered globally, so a module defining a protocol may be loaded once and used for any subsequent (read). Built-in protocols like :json cannot be redefined.`,
+		`Returns the protocol name.`,
+		`=> (defprotocol :shout (fn [src sink] (emit (str (next src) "!") sink)))`,
+		`=> (def fs (mkfs ./file "hello"))`,
+		`=> (next (read fs/file :shout))`,
+	)
 }
 
 type primPred struct {
@@ -77,6 +77,8 @@ type BasicExample struct {
 	Bind  bass.Bindings
 	Bass  string
 
+	Privileges *bass.PrivilegesConfig
+
 	Result           bass.Value
 	Meta             *bass.Scope
 	ResultConsistsOf bass.List
@@ -122,6 +124,10 @@ func (example BasicExample) Run(t *testing.T) {
 
 		ctx = zapctx.ToContext(ctx, logger)
 
+		if example.Privileges != nil {
+			ctx = bass.WithPrivileges(ctx, *example.Privileges)
+		}
+
 		reader := bass.NewInMemoryFile(example.Name, example.Bass)
 		res, err := bass.EvalFSFile(ctx, scope, reader)
 
@@ -1454,6 +1460,10 @@ func TestGroundStdlib(t *testing.T) {
 func TestGroundPipes(t *testing.T) {
 	scope := bass.NewStandardScope()
 
+	t.Cleanup(func() {
+		bass.UnregisterProtocol("shout")
+	})
+
 	type example struct {
 		Name string
 		Bass string
@@ -1528,6 +1538,21 @@ func TestGroundPipes(t *testing.T) {
 			Bass:   "(next (across (list->source [0 2 4]) (list->source [1 3 5])))",
 			Result: bass.NewList(bass.Int(0), bass.Int(1)),
 		},
+		{
+			Name:   "defprotocol",
+			Bass:   `(defprotocol :shout (fn [src sink] (emit (str (next src) "!") sink) (emit :done sink))) (let [fs (mkfs ./file "hello")] (take 2 (read fs/file :shout)))`,
+			Result: bass.NewList(bass.String("hello!"), bass.Symbol("done")),
+		},
+		{
+			Name: "read unknown protocol",
+			Bass: `(let [fs (mkfs ./file "hello")] (read fs/file :nope))`,
+			Err:  bass.UnknownProtocolError{Protocol: "nope"},
+		},
+		{
+			Name: "defprotocol built-in",
+			Bass: `(defprotocol :json (fn [src sink] (emit (next src) sink)))`,
+			Err:  bass.BuiltinProtocolError{Protocol: "json"},
+		},
 		{
 			Name: "for",
 			// NB: cheating here a bit by not going over all of them, but it's not
@@ -1877,6 +1902,232 @@ func TestBuiltinCombiners(t *testing.T) {
 				bass.String("purr"),
 			),
 		},
+		{
+			Name: "with-image-config",
+			Bass: `(with-image-config (.app) {:entrypoint ["/bin/app"] :cmd ["serve"] :user "nobody" :exposed-ports ["8080"]})`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"app"},
+				},
+				ImageConfig: &bass.ThunkImageConfig{
+					Entrypoint:   []string{"/bin/app"},
+					Cmd:          []string{"serve"},
+					User:         "nobody",
+					ExposedPorts: []string{"8080"},
+				},
+			},
+		},
+		{
+			Name: "with-image-env",
+			Bass: `(with-image-env (.go) true)`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"go"},
+				},
+				ImageEnv: true,
+			},
+		},
+		{
+			Name: "with-user",
+			Bass: `(with-user (.npm) "1000:1000")`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"npm"},
+				},
+				User: "1000:1000",
+			},
+		},
+		{
+			Name: "with-ssh",
+			Bass: `(with-ssh (.git))`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"git"},
+				},
+				SSH: true,
+			},
+		},
+		{
+			Name: "with-limits",
+			Bass: `(with-limits (.go) {:memory "4GB" :cpus 2 :pids 512 :tmpfs-size "1GB"})`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"go"},
+				},
+				Limits: &bass.ThunkLimits{
+					Memory:    "4GB",
+					CPUs:      2,
+					Pids:      512,
+					TmpfsSize: "1GB",
+				},
+			},
+		},
+		{
+			Name:        "with-limits invalid memory",
+			Bass:        `(with-limits (.go) {:memory "lots"})`,
+			ErrContains: "invalid memory limit",
+		},
+		{
+			Name: "with-network",
+			Bass: `(with-network (.go) :none)`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"go"},
+				},
+				Network: bass.NetworkNone,
+			},
+		},
+		{
+			Name:        "with-network unknown mode",
+			Bass:        `(with-network (.go) :bridge)`,
+			ErrContains: "unknown network mode: bridge",
+		},
+		{
+			Name: "with-capabilities",
+			Bass: `(with-capabilities (.ip) [:net-admin :net-raw :net-admin])`,
+			Privileges: &bass.PrivilegesConfig{
+				Capabilities: []string{"net-admin", "net-raw"},
+			},
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"ip"},
+				},
+				Capabilities: []string{"net-admin", "net-raw"},
+			},
+		},
+		{
+			Name: "with-capabilities not allowed",
+			Bass: `(with-capabilities (.ip) [:net-admin :sys-admin])`,
+			Privileges: &bass.PrivilegesConfig{
+				Capabilities: []string{"net-admin"},
+			},
+			ErrEqual: bass.PrivilegeNotAllowedError{
+				Kind: "capability",
+				Name: "sys-admin",
+				Key:  "capabilities",
+			},
+		},
+		{
+			Name: "with-capabilities unknown",
+			Bass: `(with-capabilities (.ip) [:superpowers])`,
+			Privileges: &bass.PrivilegesConfig{
+				Capabilities: []string{"superpowers"},
+			},
+			ErrContains: "unknown capability: superpowers",
+		},
+		{
+			Name: "with-device",
+			Bass: `(with-device (.sshfs) "/dev/fuse")`,
+			Privileges: &bass.PrivilegesConfig{
+				Devices: []string{"/dev/fuse"},
+			},
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"sshfs"},
+				},
+				Devices: []string{"/dev/fuse"},
+			},
+		},
+		{
+			Name: "with-device not allowed",
+			Bass: `(with-device (.sshfs) "/dev/fuse")`,
+			ErrEqual: bass.PrivilegeNotAllowedError{
+				Kind: "device",
+				Name: "/dev/fuse",
+				Key:  "devices",
+			},
+		},
+		{
+			Name: "with-device invalid",
+			Bass: `(with-device (.cat) "/etc/../dev/sda")`,
+			Privileges: &bass.PrivilegesConfig{
+				Devices: []string{"/etc/../dev/sda"},
+			},
+			ErrContains: "invalid device path",
+		},
+		{
+			Name: "with-mount",
+			Bass: `(with-mount (.ls) (subpath (.git) ./repo/) ./repo/)`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"ls"},
+				},
+				Mounts: []bass.ThunkMount{
+					{
+						Source: bass.ThunkMountSource{
+							ThunkPath: &bass.ThunkPath{
+								Thunk: bass.MustThunk(bass.CommandPath{"git"}),
+								Path:  bass.ParseFileOrDirPath("repo/"),
+							},
+						},
+						Target: bass.ParseFileOrDirPath("repo/"),
+					},
+				},
+			},
+		},
+		{
+			Name: "with-mount owner",
+			Bass: `(with-mount (.ls) (subpath (.git) ./repo/) ./repo/ {:owner "1000"})`,
+			Result: bass.Thunk{
+				Cmd: bass.ThunkCmd{
+					Cmd: &bass.CommandPath{"ls"},
+				},
+				Mounts: []bass.ThunkMount{
+					{
+						Source: bass.ThunkMountSource{
+							ThunkPath: &bass.ThunkPath{
+								Thunk: bass.MustThunk(bass.CommandPath{"git"}),
+								Path:  bass.ParseFileOrDirPath("repo/"),
+							},
+						},
+						Target: bass.ParseFileOrDirPath("repo/"),
+						Owner:  "1000",
+					},
+				},
+			},
+		},
+		{
+			Name:        "with-mount invalid owner",
+			Bass:        `(with-mount (.ls) (subpath (.git) ./repo/) ./repo/ {:owner "postgres"})`,
+			ErrContains: "invalid owner uid",
+		},
+		{
+			Name: "dockerfile",
+			Bass: `(dockerfile (subpath (.git) ./repo/))`,
+			Result: bass.Bindings{
+				"platform": bass.Bindings{
+					"os": bass.String("linux"),
+				}.Scope(),
+				"context": bass.ThunkPath{
+					Thunk: bass.MustThunk(bass.CommandPath{"git"}),
+					Path:  bass.ParseFileOrDirPath("repo/"),
+				},
+			}.Scope(),
+		},
+		{
+			Name: "dockerfile opts",
+			Bass: `(dockerfile (subpath (.git) ./repo/) {:file ./build.Dockerfile :target "build" :args {:VERSION "1.0"} :platform {:os "linux" :arch "arm64"}})`,
+			Result: bass.Bindings{
+				"platform": bass.Bindings{
+					"os":   bass.String("linux"),
+					"arch": bass.String("arm64"),
+				}.Scope(),
+				"context": bass.ThunkPath{
+					Thunk: bass.MustThunk(bass.CommandPath{"git"}),
+					Path:  bass.ParseFileOrDirPath("repo/"),
+				},
+				"dockerfile": bass.FilePath{"build.Dockerfile"},
+				"target":     bass.String("build"),
+				"args": bass.Bindings{
+					"VERSION": bass.String("1.0"),
+				}.Scope(),
+			}.Scope(),
+		},
+		{
+			Name:   "dockerfile as image",
+			Bass:   `(thunk? (from (dockerfile (subpath (.git) ./repo/)) ($ make)))`,
+			Result: bass.Bool(true),
+		},
 	} {
 		t.Run(example.Name, example.Run)
 	}
@@ -0,0 +1,305 @@
+package bass
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/errgroup"
+)
+
+// ImageIndex is a multi-platform image, composed of a thunk to run for each
+// platform.
+type ImageIndex struct {
+	Images []IndexImage `json:"images"`
+}
+
+// IndexImage is a thunk to export as the image for a platform.
+type IndexImage struct {
+	Platform Platform `json:"platform"`
+	Thunk    Thunk    `json:"thunk"`
+}
+
+// NewImageIndex is exposed as (export-multi) - it takes a scope mapping
+// platforms, e.g. :linux/amd64, to thunks and constructs an image index.
+//
+// Images are sorted by platform.
+func NewImageIndex(platformThunks *Scope) (ImageIndex, error) {
+	var index ImageIndex
+	err := platformThunks.Each(func(key Symbol, val Value) error {
+		platform, err := ParsePlatform(string(key))
+		if err != nil {
+			return err
+		}
+
+		var thunk Thunk
+		if err := val.Decode(&thunk); err != nil {
+			return fmt.Errorf("%s: decode: %w", key, err)
+		}
+
+		index.Images = append(index.Images, IndexImage{
+			Platform: platform,
+			Thunk:    thunk,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return ImageIndex{}, err
+	}
+
+	if len(index.Images) == 0 {
+		return ImageIndex{}, fmt.Errorf("export-multi: no platforms given")
+	}
+
+	sort.Slice(index.Images, func(i, j int) bool {
+		return index.Images[i].Platform.String() < index.Images[j].Platform.String()
+	})
+
+	return index, nil
+}
+
+// indexBlobLimit is the maximum size of a blob to retain in memory while
+// assembling an index. Only manifests and configs need to be read, and they
+// are much smaller than this.
+const indexBlobLimit = 1024 * 1024
+
+// Export runs each image's thunk on the runtime for its platform and writes
+// an OCI image index tarball containing all of them to w.
+//
+// The images are exported in parallel to temporary files, which are then
+// merged in order.
+func (index ImageIndex) Export(ctx context.Context, w io.Writer) error {
+	tmp, err := os.MkdirTemp("", "bass-export-multi")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmp)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, image := range index.Images {
+		archive := filepath.Join(tmp, fmt.Sprintf("%d.tar", i))
+		image := image
+		eg.Go(func() error {
+			err := exportImage(ctx, archive, image)
+			if err != nil {
+				return fmt.Errorf("export %s: %w", image.Platform, err)
+			}
+
+			return nil
+		})
+	}
+
+	err = eg.Wait()
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+
+	written := map[string]bool{}
+
+	var manifests []ocispecs.Descriptor
+	for i, image := range index.Images {
+		archive, err := os.Open(filepath.Join(tmp, fmt.Sprintf("%d.tar", i)))
+		if err != nil {
+			return err
+		}
+
+		descs, err := mergeOCILayout(tw, archive, written, image.Platform)
+		_ = archive.Close()
+		if err != nil {
+			return fmt.Errorf("merge %s: %w", image.Platform, err)
+		}
+
+		manifests = append(manifests, descs...)
+	}
+
+	layout, err := json.Marshal(ocispecs.ImageLayout{
+		Version: ocispecs.ImageLayoutVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeTarFile(tw, ocispecs.ImageLayoutFile, layout)
+	if err != nil {
+		return err
+	}
+
+	idx := ocispecs.Index{
+		MediaType: ocispecs.MediaTypeImageIndex,
+		Manifests: manifests,
+	}
+	idx.SchemaVersion = 2
+
+	payload, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	err = writeTarFile(tw, "index.json", payload)
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// exportImage exports the image's thunk as an OCI layout tarball to the given
+// path.
+func exportImage(ctx context.Context, path string, image IndexImage) error {
+	runtime, err := RuntimeFromContext(ctx, image.Platform)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = runtime.Export(ctx, file, image.Thunk)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// mergeOCILayout copies the blobs from an OCI layout tarball into tw, skipping
+// any that have already been written, and returns the descriptors from its
+// index with their platforms set.
+func mergeOCILayout(tw *tar.Writer, r io.Reader, written map[string]bool, platform Platform) ([]ocispecs.Descriptor, error) {
+	tr := tar.NewReader(r)
+
+	var idx ocispecs.Index
+	blobs := map[digest.Digest][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, err
+		}
+
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == "index.json":
+			err := json.NewDecoder(tr).Decode(&idx)
+			if err != nil {
+				return nil, fmt.Errorf("decode index: %w", err)
+			}
+		case strings.HasPrefix(name, "blobs/"):
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+
+			var content io.Reader = tr
+			if hdr.Size <= indexBlobLimit {
+				buf := new(bytes.Buffer)
+				_, err := io.Copy(buf, tr)
+				if err != nil {
+					return nil, err
+				}
+
+				dig := digest.NewDigestFromEncoded(
+					digest.Algorithm(path.Base(path.Dir(name))),
+					path.Base(name),
+				)
+
+				blobs[dig] = buf.Bytes()
+				content = buf
+			}
+
+			if written[name] {
+				continue
+			}
+
+			hdr.Name = name
+			err = tw.WriteHeader(hdr)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = io.Copy(tw, content)
+			if err != nil {
+				return nil, err
+			}
+
+			written[name] = true
+		}
+	}
+
+	if len(idx.Manifests) == 0 {
+		return nil, fmt.Errorf("no manifests in exported image")
+	}
+
+	var descs []ocispecs.Descriptor
+	for _, desc := range idx.Manifests {
+		if desc.Platform == nil {
+			desc.Platform = &ocispecs.Platform{
+				OS:           platform.OS,
+				Architecture: platform.Arch,
+			}
+
+			var manifest ocispecs.Manifest
+			if payload, found := blobs[desc.Digest]; found {
+				if err := json.Unmarshal(payload, &manifest); err != nil {
+					return nil, fmt.Errorf("decode manifest: %w", err)
+				}
+			}
+
+			var config ocispecs.Image
+			if payload, found := blobs[manifest.Config.Digest]; found {
+				if err := json.Unmarshal(payload, &config); err != nil {
+					return nil, fmt.Errorf("decode config: %w", err)
+				}
+
+				if config.OS != "" {
+					desc.Platform.OS = config.OS
+					desc.Platform.Architecture = config.Architecture
+				}
+			}
+		}
+
+		// the per-image name is meaningless in a multi-platform index
+		delete(desc.Annotations, ocispecs.AnnotationRefName)
+		if len(desc.Annotations) == 0 {
+			desc.Annotations = nil
+		}
+
+		descs = append(descs, desc)
+	}
+
+	return descs, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tw.Write(content)
+	return err
+}
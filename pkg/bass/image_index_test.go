@@ -0,0 +1,154 @@
+package bass_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+)
+
+func TestImageIndexExport(t *testing.T) {
+	is := is.New(t)
+
+	amd64 := bass.Platform{OS: "linux", Arch: "amd64"}
+	arm64 := bass.Platform{OS: "linux", Arch: "arm64"}
+
+	ctx := bass.WithRuntimePool(context.Background(), &runtimes.Pool{
+		Runtimes: []runtimes.Assoc{
+			{
+				Platform: amd64,
+				Runtime:  &FakeRuntime{Arch: "amd64"},
+			},
+			{
+				Platform: arm64,
+				Runtime:  &FakeRuntime{Arch: "arm64"},
+			},
+		},
+	})
+
+	thunk := func(msg string) bass.Thunk {
+		return bass.Thunk{
+			Image: &bass.ThunkImage{
+				Ref: &bass.ThunkImageRef{
+					Platform:   bass.LinuxPlatform,
+					Repository: "alpine",
+				},
+			},
+			Cmd: bass.ThunkCmd{
+				Cmd: &bass.CommandPath{Command: "echo"},
+			},
+			Args: []bass.Value{bass.String(msg)},
+		}
+	}
+
+	index, err := bass.NewImageIndex(bass.Bindings{
+		"linux/arm64": thunk("arm64"),
+		"linux/amd64": thunk("amd64"),
+	}.Scope())
+	is.NoErr(err)
+
+	buf := new(bytes.Buffer)
+	is.NoErr(index.Export(ctx, buf))
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		is.NoErr(err)
+
+		content, err := io.ReadAll(tr)
+		is.NoErr(err)
+
+		_, dup := files[hdr.Name]
+		is.True(!dup)
+
+		files[hdr.Name] = content
+	}
+
+	var layout ocispecs.ImageLayout
+	is.NoErr(json.Unmarshal(files[ocispecs.ImageLayoutFile], &layout))
+	is.Equal(layout.Version, ocispecs.ImageLayoutVersion)
+
+	var idx ocispecs.Index
+	is.NoErr(json.Unmarshal(files["index.json"], &idx))
+	is.Equal(idx.MediaType, ocispecs.MediaTypeImageIndex)
+	is.Equal(len(idx.Manifests), 2)
+
+	for i, platform := range []bass.Platform{amd64, arm64} {
+		desc := idx.Manifests[i]
+		is.Equal(desc.Platform, &ocispecs.Platform{
+			OS:           platform.OS,
+			Architecture: platform.Arch,
+		})
+		is.Equal(desc.Annotations, nil)
+
+		var manifest ocispecs.Manifest
+		is.NoErr(json.Unmarshal(files["blobs/sha256/"+desc.Digest.Encoded()], &manifest))
+		is.Equal(len(manifest.Layers), 1)
+
+		sha2, err := thunk(platform.Arch).SHA256()
+		is.NoErr(err)
+		is.Equal(string(files["blobs/sha256/"+manifest.Layers[0].Digest.Encoded()]), sha2)
+	}
+}
+
+func TestNewImageIndex(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{Command: "echo"},
+		},
+	}
+
+	index, err := bass.NewImageIndex(bass.Bindings{
+		"linux/arm64": thunk,
+		"linux":       thunk,
+	}.Scope())
+	is.NoErr(err)
+	is.Equal(index.Images, []bass.IndexImage{
+		{Platform: bass.LinuxPlatform, Thunk: thunk},
+		{Platform: bass.Platform{OS: "linux", Arch: "arm64"}, Thunk: thunk},
+	})
+
+	_, err = bass.NewImageIndex(bass.NewEmptyScope())
+	is.True(err != nil)
+
+	_, err = bass.NewImageIndex(bass.Bindings{
+		"linux/arm64/v8/extra": thunk,
+	}.Scope())
+	is.True(err != nil)
+
+	_, err = bass.NewImageIndex(bass.Bindings{
+		"linux/arm64": bass.Int(42),
+	}.Scope())
+	is.True(err != nil)
+}
+
+func TestExportMulti(t *testing.T) {
+	is := is.New(t)
+
+	res, err := bass.EvalString(
+		context.Background(),
+		bass.NewStandardScope(),
+		`(export-multi {:linux/amd64 (.echo) :linux/arm64 (.echo)})`,
+		bass.NewInMemoryFile("test", ""),
+	)
+	is.NoErr(err)
+
+	var index bass.ImageIndex
+	is.NoErr(res.Decode(&index))
+	is.Equal(len(index.Images), 2)
+	is.Equal(index.Images[0].Platform, bass.Platform{OS: "linux", Arch: "amd64"})
+	is.Equal(index.Images[1].Platform, bass.Platform{OS: "linux", Arch: "arm64"})
+}
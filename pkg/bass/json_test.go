@@ -122,6 +122,30 @@ var validThiccThunk = bass.Thunk{
 	},
 	Env:    stableEnv,
 	Labels: stableLabels,
+	ImageConfig: &bass.ThunkImageConfig{
+		Entrypoint: []string{"/bin/app"},
+		Cmd:        []string{"serve"},
+		Env: bass.Bindings{
+			"PORT": bass.String("8080"),
+		}.Scope(),
+		User: "nobody",
+		Labels: bass.Bindings{
+			"version": bass.String("1.0"),
+		}.Scope(),
+		ExposedPorts: []string{"8080/tcp"},
+	},
+	ImageEnv: true,
+	User:     "1000:1000",
+	Limits: &bass.ThunkLimits{
+		Memory:    "4GB",
+		CPUs:      2,
+		Pids:      512,
+		TmpfsSize: "1GB",
+	},
+	Network:      bass.NetworkNone,
+	Capabilities: []string{"net-admin"},
+	Devices:      []string{"/dev/fuse"},
+	SSH:          true,
 }
 
 var validThunkImageRefs = []bass.ThunkImageRef{
@@ -191,6 +215,41 @@ var validThunkImages = []bass.ThunkImage{
 	{
 		Thunk: &validBasicThunk,
 	},
+	{
+		DockerBuild: &bass.ThunkDockerBuild{
+			Platform: bass.Platform{
+				OS: "os",
+			},
+			Context: bass.ImageBuildInput{
+				Thunk: &bass.ThunkPath{
+					Thunk: validBasicThunk,
+					Path:  bass.ParseFileOrDirPath("context/"),
+				},
+			},
+			// no dockerfile
+			// no target
+			// no args
+		},
+	},
+	{
+		DockerBuild: &bass.ThunkDockerBuild{
+			Platform: bass.Platform{
+				OS:   "os",
+				Arch: "arch",
+			},
+			Context: bass.ImageBuildInput{
+				Host: &bass.HostPath{
+					ContextDir: "/some/dir",
+					Path:       bass.ParseFileOrDirPath("context/"),
+				},
+			},
+			Dockerfile: bass.FilePath{"build.Dockerfile"},
+			Target:     "build",
+			Args: bass.Bindings{
+				"VERSION": bass.String("1.0"),
+			}.Scope(),
+		},
+	},
 }
 
 func init() {
@@ -312,6 +371,11 @@ func init() {
 				Source: src,
 				Target: bass.ParseFileOrDirPath("mount/file"),
 			},
+			bass.ThunkMount{
+				Source: src,
+				Target: bass.ParseFileOrDirPath("mount/owned/"),
+				Owner:  "1000:1000",
+			},
 		)
 		encodable = append(encodable, thunk)
 	}
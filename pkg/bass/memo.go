@@ -1,25 +1,90 @@
 package bass
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/gofrs/flock"
 	"github.com/vito/bass/pkg/proto"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Memos is where memoized calls are cached.
 type Memos interface {
-	Store(Thunk, Symbol, Value, Value) error
+	// Store records the output for the input, replacing any existing output.
+	//
+	// The output expires after the given TTL, or never if it is zero.
+	Store(Thunk, Symbol, Value, Value, time.Duration) error
+
+	// Retrieve returns the output recorded for the input.
 	Retrieve(Thunk, Symbol, Value) (Value, bool, error)
+
 	Remove(Thunk, Symbol, Value) error
 }
 
+// MemoOpts contains optional parameters for memoized calls.
+type MemoOpts struct {
+	// TTL is how long a result remains valid; see ParseMemoTTL.
+	TTL Value `json:"ttl,omitempty"`
+}
+
+// memoTTLUnits are the named durations accepted by ParseMemoTTL.
+var memoTTLUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+	"week":   7 * 24 * time.Hour,
+}
+
+// ParseMemoTTL parses a TTL given as a number of seconds, a named unit like
+// "day" or "week", or a duration string like "36h" as accepted by
+// time.ParseDuration.
+//
+// A nil value is parsed as zero, meaning the result never expires.
+func ParseMemoTTL(val Value) (time.Duration, error) {
+	if val == nil {
+		return 0, nil
+	}
+
+	var ttl time.Duration
+
+	var secs int
+	var str string
+	if err := val.Decode(&secs); err == nil {
+		ttl = time.Duration(secs) * time.Second
+	} else if err := val.Decode(&str); err == nil {
+		unit, found := memoTTLUnits[str]
+		if found {
+			ttl = unit
+		} else {
+			ttl, err = time.ParseDuration(str)
+			if err != nil {
+				return 0, fmt.Errorf("invalid ttl: %w", err)
+			}
+		}
+	} else {
+		return 0, fmt.Errorf("invalid ttl: %s", val)
+	}
+
+	if ttl < 0 {
+		return 0, fmt.Errorf("invalid ttl: %s", val)
+	}
+
+	return ttl, nil
+}
+
 func init() {
 	Ground.Set("recall-memo",
 		Func("recall-memo", "[memos thunk binding input]", func(ctx context.Context, memos Readable, thunk Thunk, binding Symbol, input Value) (Value, error) {
@@ -28,6 +93,15 @@ func init() {
 				return nil, fmt.Errorf("open memos at %s: %w", memos, err)
 			}
 
+			if rec := memoRecorderFromContext(ctx); rec != nil {
+				if lockfile := memoLockfile(memo); lockfile != nil {
+					err := rec.record(lockfile.path, thunk, binding, input)
+					if err != nil {
+						return nil, fmt.Errorf("record memo %s:%s: %w", thunk, binding, err)
+					}
+				}
+			}
+
 			res, found, err := memo.Retrieve(thunk, binding, input)
 			if err != nil {
 				return nil, fmt.Errorf("retrieve memo %s:%s: %w", thunk, binding, err)
@@ -44,13 +118,26 @@ func init() {
 		`See (memo) for the higher-level interface.`)
 
 	Ground.Set("store-memo",
-		Func("store-memo", "[memos thunk binding input result]", func(ctx context.Context, memos Readable, thunk Thunk, binding Symbol, input Value, res Value) (Value, error) {
+		Func("store-memo", "[memos thunk binding input result & opts]", func(ctx context.Context, memos Readable, thunk Thunk, binding Symbol, input Value, res Value, optsScope ...*Scope) (Value, error) {
+			var opts MemoOpts
+			if len(optsScope) > 0 {
+				err := optsScope[0].Decode(&opts)
+				if err != nil {
+					return nil, fmt.Errorf("memo opts: %w", err)
+				}
+			}
+
+			ttl, err := ParseMemoTTL(opts.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("memo opts: %w", err)
+			}
+
 			memo, err := OpenMemos(ctx, memos)
 			if err != nil {
 				return nil, fmt.Errorf("open memos at %s: %w", memos, err)
 			}
 
-			err = memo.Store(thunk, binding, input, res)
+			err = memo.Store(thunk, binding, input, res, ttl)
 			if err != nil {
 				return nil, fmt.Errorf("store memo %s:%s: %w", thunk, binding, err)
 			}
@@ -58,12 +145,58 @@ func init() {
 			return res, nil
 		}),
 		`stores the result of a memoized function call`,
+		`Accepts an optional scope of options. Set :ttl to a duration after which the result expires, either a number of seconds, a unit like "day" or "week", or a string like "36h".`,
 		`See (memo) for the higher-level interface.`)
 }
 
+// memoLockfile returns the lockfile which the memos are stored in, if any.
+func memoLockfile(memos Memos) *Lockfile {
+	switch x := memos.(type) {
+	case *Lockfile:
+		return x
+	case *LayeredMemos:
+		return x.Lockfile
+	default:
+		return nil
+	}
+}
+
+// Lockfile stores memos in a single file, guarded by a file lock.
 type Lockfile struct {
-	path string
-	lock *flock.Flock
+	path   string
+	lock   *flock.Flock
+	format memoFormat
+}
+
+// memoFormat is the encoding used for a Lockfile's content.
+type memoFormat struct {
+	Marshal   func(*proto.Memosphere) ([]byte, error)
+	Unmarshal func([]byte, *proto.Memosphere) error
+}
+
+var prototextFormat = memoFormat{
+	Marshal: MarshalMemos,
+	Unmarshal: func(payload []byte, content *proto.Memosphere) error {
+		return prototext.Unmarshal(payload, content)
+	},
+}
+
+var protojsonFormat = memoFormat{
+	Marshal: func(content *proto.Memosphere) ([]byte, error) {
+		err := SortMemos(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return (protojson.MarshalOptions{Multiline: true}).Marshal(content)
+	},
+	Unmarshal: func(payload []byte, content *proto.Memosphere) error {
+		if len(bytes.TrimSpace(payload)) == 0 {
+			return nil
+		}
+
+		return protojson.Unmarshal(payload, content)
+	},
 }
 
 func OpenMemos(ctx context.Context, readable Readable) (Memos, error) {
@@ -74,7 +207,16 @@ func OpenMemos(ctx context.Context, readable Readable) (Memos, error) {
 
 	var hostPath HostPath
 	if err := readable.Decode(&hostPath); err == nil {
-		return NewLockfileMemo(cacheLockfile), nil
+		lockfile := NewLockfileMemo(cacheLockfile)
+
+		if shared := memosFromContext(ctx); shared != nil {
+			return &LayeredMemos{
+				Lockfile: lockfile,
+				Shared:   shared,
+			}, nil
+		}
+
+		return lockfile, nil
 	}
 
 	lockContent, err := os.ReadFile(cacheLockfile)
@@ -97,15 +239,31 @@ type ReadonlyMemos struct {
 
 var _ Memos = &ReadonlyMemos{}
 
-func (file ReadonlyMemos) Store(thunk Thunk, binding Symbol, input Value, output Value) error {
+func (file ReadonlyMemos) Store(thunk Thunk, binding Symbol, input Value, output Value, ttl time.Duration) error {
 	return nil
 }
 
+// Retrieve returns the recorded output, even if it has expired, since it
+// cannot be refreshed.
 func (file ReadonlyMemos) Retrieve(thunk Thunk, binding Symbol, input Value) (Value, bool, error) {
-	return retrieveMemo(file.Content, thunk, binding, input)
+	return retrieveMemo(file.Content, thunk, binding, input, true)
 }
 
-func retrieveMemo(content *proto.Memosphere, thunk Thunk, binding Symbol, input Value) (Value, bool, error) {
+func retrieveMemo(content *proto.Memosphere, thunk Thunk, binding Symbol, input Value, allowExpired bool) (Value, bool, error) {
+	res, found, err := retrieveMemoResult(content, thunk, binding, input, allowExpired)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	val, err := FromProto(res.Output)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+func retrieveMemoResult(content *proto.Memosphere, thunk Thunk, binding Symbol, input Value, allowExpired bool) (*proto.Memosphere_Result, bool, error) {
 	tp, err := thunk.Proto()
 	if err != nil {
 		return nil, false, err
@@ -131,12 +289,11 @@ func retrieveMemo(content *proto.Memosphere, thunk Thunk, binding Symbol, input
 					continue
 				}
 
-				val, err := FromProto(res.Output)
-				if err != nil {
-					return nil, false, err
+				if !allowExpired && MemoExpired(res) {
+					return nil, false, nil
 				}
 
-				return val, true, nil
+				return res, true, nil
 			}
 		}
 	}
@@ -148,10 +305,22 @@ func (file ReadonlyMemos) Remove(thunk Thunk, binding Symbol, input Value) error
 	return nil
 }
 
+// NewLockfileMemo returns a Lockfile which stores memos as prototext, the
+// format used for bass.lock.
 func NewLockfileMemo(path string) *Lockfile {
 	return &Lockfile{
-		path: path,
-		lock: flock.New(path),
+		path:   path,
+		lock:   flock.New(path),
+		format: prototextFormat,
+	}
+}
+
+// NewJSONMemo returns a Lockfile which stores memos as JSON.
+func NewJSONMemo(path string) *Lockfile {
+	return &Lockfile{
+		path:   path,
+		lock:   flock.New(path),
+		format: protojsonFormat,
 	}
 }
 
@@ -159,7 +328,35 @@ var _ Memos = &Lockfile{}
 
 var globalLock = new(sync.RWMutex)
 
-func (file *Lockfile) Store(thunk Thunk, binding Symbol, input Value, output Value) error {
+func (file *Lockfile) Store(thunk Thunk, binding Symbol, input Value, output Value, ttl time.Duration) error {
+	op, err := MarshalProto(output)
+	if err != nil {
+		return err
+	}
+
+	return file.update(thunk, binding, input, func(res *proto.Memosphere_Result) {
+		UpdateMemoResult(res, op, ttl)
+	})
+}
+
+// StoreResult records a result as-is, keeping its recorded time and TTL.
+//
+// If the result has no recorded time, it is recorded as of now.
+func (file *Lockfile) StoreResult(thunk Thunk, binding Symbol, input Value, result *proto.Memosphere_Result) error {
+	return file.update(thunk, binding, input, func(res *proto.Memosphere_Result) {
+		res.Output = result.Output
+		res.RecordedAt = result.RecordedAt
+		res.Ttl = result.Ttl
+
+		if res.RecordedAt == nil {
+			res.RecordedAt = timestamppb.New(Clock.Now().UTC().Truncate(time.Second))
+		}
+	})
+}
+
+// update calls fn with the result for the input, adding it if it doesn't
+// exist, and saves the lockfile.
+func (file *Lockfile) update(thunk Thunk, binding Symbol, input Value, fn func(*proto.Memosphere_Result)) error {
 	err := file.lock.Lock()
 	if err != nil {
 		return fmt.Errorf("lock: %w", err)
@@ -185,9 +382,14 @@ func (file *Lockfile) Store(thunk Thunk, binding Symbol, input Value, output Val
 		return err
 	}
 
-	op, err := MarshalProto(output)
-	if err != nil {
-		return err
+	newResult := func() *proto.Memosphere_Result {
+		res := &proto.Memosphere_Result{
+			Input: ip,
+		}
+
+		fn(res)
+
+		return res
 	}
 
 	var foundMod, foundCall, updated bool
@@ -212,26 +414,18 @@ func (file *Lockfile) Store(thunk Thunk, binding Symbol, input Value, output Val
 
 				updated = true
 
-				res.Output = op
+				fn(res)
 			}
 
 			if !updated {
-				call.Results = append(call.Results, &proto.Memosphere_Result{
-					Input:  ip,
-					Output: op,
-				})
+				call.Results = append(call.Results, newResult())
 			}
 		}
 
 		if !foundCall {
 			memo.Calls = append(memo.Calls, &proto.Memosphere_Call{
 				Binding: binding.String(),
-				Results: []*proto.Memosphere_Result{
-					{
-						Input:  ip,
-						Output: op,
-					},
-				},
+				Results: []*proto.Memosphere_Result{newResult()},
 			})
 		}
 	}
@@ -242,12 +436,7 @@ func (file *Lockfile) Store(thunk Thunk, binding Symbol, input Value, output Val
 			Calls: []*proto.Memosphere_Call{
 				{
 					Binding: binding.String(),
-					Results: []*proto.Memosphere_Result{
-						{
-							Input:  ip,
-							Output: op,
-						},
-					},
+					Results: []*proto.Memosphere_Result{newResult()},
 				},
 			},
 		})
@@ -272,7 +461,65 @@ func (file *Lockfile) Retrieve(thunk Thunk, binding Symbol, input Value) (Value,
 		return nil, false, fmt.Errorf("load lock file: %w", err)
 	}
 
-	return retrieveMemo(content, thunk, binding, input)
+	return retrieveMemo(content, thunk, binding, input, false)
+}
+
+func (file *Lockfile) RetrieveResult(thunk Thunk, binding Symbol, input Value) (*proto.Memosphere_Result, bool, error) {
+	err := file.lock.RLock()
+	if err != nil {
+		return nil, false, fmt.Errorf("lock: %w", err)
+	}
+
+	defer file.lock.Unlock()
+
+	globalLock.RLock()
+	defer globalLock.RUnlock()
+
+	content, err := file.load()
+	if err != nil {
+		return nil, false, fmt.Errorf("load lock file: %w", err)
+	}
+
+	return retrieveMemoResult(content, thunk, binding, input, false)
+}
+
+// UpdateMemoResult sets the result's output and TTL.
+//
+// The time the result was recorded is only updated when the output changed or
+// the previous result expired, so that lockfiles don't churn when nothing has
+// changed.
+func UpdateMemoResult(res *proto.Memosphere_Result, output *proto.Value, ttl time.Duration) {
+	changed := !gproto.Equal(res.Output, output)
+
+	res.Output = output
+
+	if ttl > 0 {
+		res.Ttl = durationpb.New(ttl)
+	} else {
+		res.Ttl = nil
+	}
+
+	if changed || res.RecordedAt == nil || MemoExpired(res) {
+		res.RecordedAt = timestamppb.New(Clock.Now().UTC().Truncate(time.Second))
+	}
+}
+
+// MemoExpired returns true if the result was recorded with a TTL which has
+// elapsed.
+//
+// Results with a TTL but no recorded time are considered expired.
+func MemoExpired(res *proto.Memosphere_Result) bool {
+	if res.Ttl == nil {
+		return false
+	}
+
+	if res.RecordedAt == nil {
+		return true
+	}
+
+	expiresAt := res.RecordedAt.AsTime().Add(res.Ttl.AsDuration())
+
+	return !Clock.Now().Before(expiresAt)
 }
 
 func (file *Lockfile) Remove(thunk Thunk, binding Symbol, input Value) error {
@@ -341,6 +588,36 @@ func (file *Lockfile) Remove(thunk Thunk, binding Symbol, input Value) error {
 	return file.save(content)
 }
 
+// Prune removes every result which was not recalled from the lockfile, as
+// with MemoRecorder.Prune, holding the same lock as Store while rewriting it.
+func (file *Lockfile) Prune(rec *MemoRecorder) (int, error) {
+	err := file.lock.Lock()
+	if err != nil {
+		return 0, fmt.Errorf("lock: %w", err)
+	}
+
+	defer file.lock.Unlock()
+
+	globalLock.Lock()
+	defer globalLock.Unlock()
+
+	content, err := file.load()
+	if err != nil {
+		return 0, fmt.Errorf("load lock file: %w", err)
+	}
+
+	pruned, err := rec.Prune(file.path, content)
+	if err != nil {
+		return 0, err
+	}
+
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	return pruned, file.save(content)
+}
+
 func (file *Lockfile) load() (*proto.Memosphere, error) {
 	payload, err := os.ReadFile(file.path)
 	if err != nil {
@@ -348,7 +625,7 @@ func (file *Lockfile) load() (*proto.Memosphere, error) {
 	}
 
 	content := &proto.Memosphere{}
-	err = prototext.Unmarshal(payload, content)
+	err = file.format.Unmarshal(payload, content)
 	if err != nil {
 		if errors.Is(err, gproto.Error) {
 			return content, nil
@@ -361,10 +638,398 @@ func (file *Lockfile) load() (*proto.Memosphere, error) {
 }
 
 func (file *Lockfile) save(content *proto.Memosphere) error {
-	payload, err := (prototext.MarshalOptions{Multiline: true}).Marshal(content)
+	payload, err := file.format.Marshal(content)
 	if err != nil {
 		return err
 	}
 
 	return os.WriteFile(file.path, payload, 0644)
 }
+
+// MarshalMemos sorts the content into canonical order and marshals it as
+// multiline prototext, suitable for writing to a lockfile.
+func MarshalMemos(content *proto.Memosphere) ([]byte, error) {
+	err := SortMemos(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return (prototext.MarshalOptions{Multiline: true}).Marshal(content)
+}
+
+// SortMemos sorts memos by module, calls by binding, and results by input, so
+// that lockfiles written on different branches can be merged cleanly.
+func SortMemos(content *proto.Memosphere) error {
+	keys := map[gproto.Message][]byte{}
+	key := func(msg gproto.Message) ([]byte, error) {
+		k, found := keys[msg]
+		if found {
+			return k, nil
+		}
+
+		k, err := memoKey(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[msg] = k
+
+		return k, nil
+	}
+
+	var errs error
+	less := func(a, b gproto.Message) bool {
+		ka, err := key(a)
+		if err != nil {
+			errs = err
+			return false
+		}
+
+		kb, err := key(b)
+		if err != nil {
+			errs = err
+			return false
+		}
+
+		return bytes.Compare(ka, kb) < 0
+	}
+
+	sort.SliceStable(content.Memos, func(i, j int) bool {
+		return less(content.Memos[i].Module, content.Memos[j].Module)
+	})
+
+	for _, memo := range content.Memos {
+		sort.SliceStable(memo.Calls, func(i, j int) bool {
+			return memo.Calls[i].Binding < memo.Calls[j].Binding
+		})
+
+		for _, call := range memo.Calls {
+			sort.SliceStable(call.Results, func(i, j int) bool {
+				return less(call.Results[i].Input, call.Results[j].Input)
+			})
+		}
+	}
+
+	return errs
+}
+
+// memoKey returns a stable binary encoding of the message for comparison.
+func memoKey(msg gproto.Message) ([]byte, error) {
+	return (gproto.MarshalOptions{Deterministic: true}).Marshal(msg)
+}
+
+// MemoConflict is a memoized call whose result was changed in conflicting
+// ways by both sides of a merge.
+//
+// A nil output indicates that the result was absent on that side.
+type MemoConflict struct {
+	Module  *proto.Thunk
+	Binding string
+	Input   *proto.Value
+	Base    *proto.Value
+	Ours    *proto.Value
+	Theirs  *proto.Value
+}
+
+// MergeMemos performs a three-way merge of memos, taking each result changed
+// on only one side and reporting each result changed on both sides as
+// a conflict.
+//
+// Conflicting results are resolved in favor of ours in the returned content.
+func MergeMemos(base, ours, theirs *proto.Memosphere) (*proto.Memosphere, []MemoConflict, error) {
+	baseResults, err := flattenMemos(base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ourResults, err := flattenMemos(ours)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	theirResults, err := flattenMemos(theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys := map[string]memoResult{}
+	for k, res := range baseResults {
+		keys[k] = res
+	}
+	for k, res := range ourResults {
+		keys[k] = res
+	}
+	for k, res := range theirResults {
+		keys[k] = res
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+
+	sort.Strings(sortedKeys)
+
+	merged := &proto.Memosphere{}
+	var conflicts []MemoConflict
+	for _, k := range sortedKeys {
+		res := keys[k]
+
+		b := baseResults[k].Result.GetOutput()
+		o := ourResults[k].Result.GetOutput()
+		t := theirResults[k].Result.GetOutput()
+
+		var result *proto.Memosphere_Result
+		switch {
+		case outputsEqual(o, t):
+			result = ourResults[k].Result
+		case outputsEqual(b, o):
+			result = theirResults[k].Result
+		case outputsEqual(b, t):
+			result = ourResults[k].Result
+		default:
+			conflicts = append(conflicts, MemoConflict{
+				Module:  res.Module,
+				Binding: res.Binding,
+				Input:   res.Result.Input,
+				Base:    b,
+				Ours:    o,
+				Theirs:  t,
+			})
+
+			result = ourResults[k].Result
+		}
+
+		if result == nil {
+			// removed
+			continue
+		}
+
+		addMemoResult(merged, res.Module, res.Binding, result)
+	}
+
+	err = SortMemos(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return merged, conflicts, nil
+}
+
+type memoResult struct {
+	Module  *proto.Thunk
+	Binding string
+	Result  *proto.Memosphere_Result
+}
+
+// flattenMemos indexes each result by its module, binding, and input.
+func flattenMemos(content *proto.Memosphere) (map[string]memoResult, error) {
+	results := map[string]memoResult{}
+	for _, memo := range content.Memos {
+		mk, err := memoKey(memo.Module)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, call := range memo.Calls {
+			for _, res := range call.Results {
+				ik, err := memoKey(res.Input)
+				if err != nil {
+					return nil, err
+				}
+
+				results[memoResultKey(mk, call.Binding, ik)] = memoResult{
+					Module:  memo.Module,
+					Binding: call.Binding,
+					Result:  res,
+				}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func memoResultKey(moduleKey []byte, binding string, inputKey []byte) string {
+	return fmt.Sprintf("%x\x00%s\x00%x", moduleKey, binding, inputKey)
+}
+
+func outputsEqual(a, b *proto.Value) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return gproto.Equal(a, b)
+}
+
+func addMemoResult(content *proto.Memosphere, module *proto.Thunk, binding string, result *proto.Memosphere_Result) {
+	var memo *proto.Memosphere_Memo
+	for _, m := range content.Memos {
+		if gproto.Equal(m.Module, module) {
+			memo = m
+			break
+		}
+	}
+
+	if memo == nil {
+		memo = &proto.Memosphere_Memo{Module: module}
+		content.Memos = append(content.Memos, memo)
+	}
+
+	var call *proto.Memosphere_Call
+	for _, c := range memo.Calls {
+		if c.Binding == binding {
+			call = c
+			break
+		}
+	}
+
+	if call == nil {
+		call = &proto.Memosphere_Call{Binding: binding}
+		memo.Calls = append(memo.Calls, call)
+	}
+
+	call.Results = append(call.Results, result)
+}
+
+// MemoRecorder records which memoized calls are recalled from lockfiles, so
+// that results which are no longer referenced can be pruned.
+type MemoRecorder struct {
+	recalled  map[string]map[string]bool
+	recalledL sync.Mutex
+}
+
+type memoRecorderKey struct{}
+
+// RecordMemos returns a context which records every call passed to
+// (recall-memo) into the returned MemoRecorder.
+func RecordMemos(ctx context.Context) (context.Context, *MemoRecorder) {
+	rec := &MemoRecorder{
+		recalled: map[string]map[string]bool{},
+	}
+
+	return context.WithValue(ctx, memoRecorderKey{}, rec), rec
+}
+
+func memoRecorderFromContext(ctx context.Context) *MemoRecorder {
+	rec := ctx.Value(memoRecorderKey{})
+	if rec != nil {
+		return rec.(*MemoRecorder)
+	}
+
+	return nil
+}
+
+func (rec *MemoRecorder) record(path string, thunk Thunk, binding Symbol, input Value) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	tp, err := thunk.Proto()
+	if err != nil {
+		return err
+	}
+
+	mk, err := memoKey(tp)
+	if err != nil {
+		return err
+	}
+
+	ip, err := MarshalProto(input)
+	if err != nil {
+		return err
+	}
+
+	ik, err := memoKey(ip)
+	if err != nil {
+		return err
+	}
+
+	rec.recalledL.Lock()
+	defer rec.recalledL.Unlock()
+
+	keys, found := rec.recalled[path]
+	if !found {
+		keys = map[string]bool{}
+		rec.recalled[path] = keys
+	}
+
+	keys[memoResultKey(mk, binding.String(), ik)] = true
+
+	return nil
+}
+
+// Prune removes every result from the content which was not recalled from the
+// lockfile at the given path, along with any calls and modules left empty.
+//
+// If nothing was recalled from the path at all, NothingRecalledError is
+// returned rather than pruning every result.
+//
+// The number of results removed is returned.
+func (rec *MemoRecorder) Prune(path string, content *proto.Memosphere) (int, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+
+	rec.recalledL.Lock()
+	keys, found := rec.recalled[path]
+	rec.recalledL.Unlock()
+
+	if !found {
+		return 0, NothingRecalledError{Path: path}
+	}
+
+	var pruned int
+
+	memos := content.Memos[:0]
+	for _, memo := range content.Memos {
+		mk, err := memoKey(memo.Module)
+		if err != nil {
+			return 0, err
+		}
+
+		calls := memo.Calls[:0]
+		for _, call := range memo.Calls {
+			results := call.Results[:0]
+			for _, res := range call.Results {
+				ik, err := memoKey(res.Input)
+				if err != nil {
+					return 0, err
+				}
+
+				if keys[memoResultKey(mk, call.Binding, ik)] {
+					results = append(results, res)
+				} else {
+					pruned++
+				}
+			}
+
+			if len(results) > 0 {
+				call.Results = results
+				calls = append(calls, call)
+			}
+		}
+
+		if len(calls) > 0 {
+			memo.Calls = calls
+			memos = append(memos, memo)
+		}
+	}
+
+	content.Memos = memos
+
+	return pruned, nil
+}
+
+// NothingRecalledError is returned when pruning a lockfile from which no
+// memos were recalled, which more likely indicates a mismatched path than
+// a lockfile whose memos are all unused.
+type NothingRecalledError struct {
+	Path string
+}
+
+func (err NothingRecalledError) Error() string {
+	return fmt.Sprintf("no memos were recalled from %s; refusing to prune", err.Path)
+}
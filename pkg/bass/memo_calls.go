@@ -0,0 +1,286 @@
+package bass
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/vito/bass/pkg/proto"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// MemoCall is a single memoized call recorded in a lockfile.
+type MemoCall struct {
+	Module  Thunk
+	Binding Symbol
+	Comb    Combiner
+	Input   Value
+
+	// Result is the result recorded in the lockfile. Modifying its Output
+	// modifies the lockfile content it was loaded from.
+	Result *proto.Memosphere_Result
+}
+
+// Resolve calls the memoized function, returning its output.
+func (call MemoCall) Resolve(ctx context.Context) (*proto.Value, error) {
+	out, err := Trampoline(ctx, call.Comb.Call(ctx, call.Input, NewEmptyScope(), Identity))
+	if err != nil {
+		return nil, err
+	}
+
+	return MarshalProto(out)
+}
+
+// MemoFilter limits which calls in a lockfile are resolved.
+//
+// An empty filter matches everything.
+type MemoFilter struct {
+	// Only contains module or module:binding pairs.
+	Only []MemoSelector
+
+	// Inputs contains values which must be equal to the call's input or one of
+	// its arguments.
+	Inputs []Value
+}
+
+// MemoSelector selects calls by module and, optionally, binding.
+//
+// The module is matched by the name of its command, with or without its file
+// extension. An empty module matches any module.
+type MemoSelector struct {
+	Module  string
+	Binding Symbol
+}
+
+// ParseMemoFilter parses a filter from module[:binding] selectors and input
+// values.
+//
+// Inputs are parsed as JSON, falling back to a plain string.
+func ParseMemoFilter(only []string, inputs []string) (MemoFilter, error) {
+	var filter MemoFilter
+	for _, sel := range only {
+		mod, binding, _ := strings.Cut(sel, ":")
+		if mod == "" && binding == "" {
+			return MemoFilter{}, fmt.Errorf("invalid memo selector: %q", sel)
+		}
+
+		filter.Only = append(filter.Only, MemoSelector{
+			Module:  mod,
+			Binding: Symbol(binding),
+		})
+	}
+
+	for _, input := range inputs {
+		var val Value
+		err := UnmarshalJSON([]byte(input), &val)
+		if err != nil {
+			// not JSON; treat it as a plain string
+			val = String(input)
+		}
+
+		filter.Inputs = append(filter.Inputs, val)
+	}
+
+	return filter, nil
+}
+
+// MatchesModule returns true if any of the calls in the module may match.
+func (filter MemoFilter) MatchesModule(module Thunk) bool {
+	if len(filter.Only) == 0 {
+		return true
+	}
+
+	for _, sel := range filter.Only {
+		if sel.matchesModule(module) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesCall returns true if the call should be resolved.
+func (filter MemoFilter) MatchesCall(module Thunk, binding Symbol, input Value) bool {
+	if len(filter.Only) > 0 {
+		var matched bool
+		for _, sel := range filter.Only {
+			if sel.matchesModule(module) && (sel.Binding == "" || sel.Binding == binding) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if len(filter.Inputs) == 0 {
+		return true
+	}
+
+	var args []Value
+	var list List
+	if err := input.Decode(&list); err == nil {
+		args, _ = ToSlice(list)
+	}
+
+	for _, val := range filter.Inputs {
+		if val.Equal(input) {
+			return true
+		}
+
+		for _, arg := range args {
+			if val.Equal(arg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (sel MemoSelector) matchesModule(module Thunk) bool {
+	if sel.Module == "" {
+		return true
+	}
+
+	var cmdPath Path
+	if err := module.Cmd.ToValue().Decode(&cmdPath); err != nil {
+		return false
+	}
+
+	name := cmdPath.Name()
+
+	return sel.Module == name || sel.Module == strings.TrimSuffix(name, path.Ext(name))
+}
+
+// MemoCalls loads each module in the lockfile and returns every call recorded
+// for it which matches the filter.
+func MemoCalls(ctx context.Context, content *proto.Memosphere, filter MemoFilter) ([]MemoCall, error) {
+	var calls []MemoCall
+	for _, memo := range content.Memos {
+		thunk := Thunk{}
+		err := thunk.UnmarshalProto(memo.Module)
+		if err != nil {
+			return nil, err
+		}
+
+		if !filter.MatchesModule(thunk) {
+			continue
+		}
+
+		scope, err := Bass.Load(ctx, thunk)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, call := range memo.Calls {
+			binding := Symbol(call.Binding)
+
+			var comb Combiner
+			err = scope.GetDecode(binding, &comb)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, res := range call.Results {
+				input, err := FromProto(res.Input)
+				if err != nil {
+					return nil, err
+				}
+
+				if !filter.MatchesCall(thunk, binding, input) {
+					continue
+				}
+
+				calls = append(calls, MemoCall{
+					Module:  thunk,
+					Binding: binding,
+					Comb:    comb,
+					Input:   input,
+					Result:  res,
+				})
+			}
+		}
+	}
+
+	return calls, nil
+}
+
+// ResolveMemoCalls resolves each call in parallel, limited to the given
+// number of jobs at a time, returning their outputs in the same order.
+func ResolveMemoCalls(ctx context.Context, calls []MemoCall, jobs int) ([]*proto.Value, error) {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	sem := semaphore.NewWeighted(int64(jobs))
+
+	outputs := make([]*proto.Value, len(calls))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, call := range calls {
+		i, call := i, call
+
+		if err := sem.Acquire(ctx, 1); err != nil {
+			break
+		}
+
+		eg.Go(func() error {
+			defer sem.Release(1)
+
+			ctx := ForkTrace(ctx) // each goroutine must have its own trace
+
+			output, err := call.Resolve(ctx)
+			if err != nil {
+				return fmt.Errorf("%s: %w", Pair{A: call.Binding, D: call.Input}, err)
+			}
+
+			outputs[i] = output
+
+			return nil
+		})
+	}
+
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// MemoChanges compares each call's recorded output with the given outputs,
+// returning the ones that differ.
+func MemoChanges(calls []MemoCall, outputs []*proto.Value) ([]StaleMemo, error) {
+	var changes []StaleMemo
+	for i, call := range calls {
+		if gproto.Equal(outputs[i], call.Result.Output) {
+			continue
+		}
+
+		locked, err := FromProto(call.Result.Output)
+		if err != nil {
+			return nil, err
+		}
+
+		actual, err := FromProto(outputs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, StaleMemo{
+			Module:  call.Module,
+			Binding: call.Binding,
+			Input:   call.Input,
+			Locked:  locked,
+			Actual:  actual,
+		})
+	}
+
+	return changes, nil
+}
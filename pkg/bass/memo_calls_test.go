@@ -0,0 +1,207 @@
+package bass_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/basstest"
+	"github.com/vito/bass/pkg/proto"
+	"github.com/vito/is"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+func TestParseMemoFilter(t *testing.T) {
+	is := is.New(t)
+
+	filter, err := bass.ParseMemoFilter(
+		[]string{"mod", "mod:fn", ":fn"},
+		[]string{`"str"`, `42`, `not json`},
+	)
+	is.NoErr(err)
+	is.Equal(filter.Only, []bass.MemoSelector{
+		{Module: "mod"},
+		{Module: "mod", Binding: "fn"},
+		{Binding: "fn"},
+	})
+	is.Equal(len(filter.Inputs), 3)
+	basstest.Equal(t, filter.Inputs[0], bass.String("str"))
+	basstest.Equal(t, filter.Inputs[1], bass.Int(42))
+	basstest.Equal(t, filter.Inputs[2], bass.String("not json"))
+
+	_, err = bass.ParseMemoFilter([]string{":"}, nil)
+	is.True(err != nil)
+}
+
+func TestMemoFilterMatches(t *testing.T) {
+	mod := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			File: &bass.FilePath{Path: "some/mod.bass"},
+		},
+	}
+
+	other := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			File: &bass.FilePath{Path: "some/other.bass"},
+		},
+	}
+
+	input := bass.NewList(bass.String("a"), bass.Int(1))
+
+	for _, example := range []struct {
+		Name   string
+		Only   []string
+		Inputs []string
+
+		Module  bass.Thunk
+		Binding bass.Symbol
+
+		MatchesModule bool
+		MatchesCall   bool
+	}{
+		{
+			Name:          "empty",
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "module by name",
+			Only:          []string{"mod.bass"},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "module without extension",
+			Only:          []string{"mod"},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "other module",
+			Only:          []string{"mod"},
+			Module:        other,
+			Binding:       "fn",
+			MatchesModule: false,
+			MatchesCall:   false,
+		},
+		{
+			Name:          "binding",
+			Only:          []string{"mod:fn"},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "other binding",
+			Only:          []string{"mod:fn"},
+			Module:        mod,
+			Binding:       "other-fn",
+			MatchesModule: true,
+			MatchesCall:   false,
+		},
+		{
+			Name:          "binding in any module",
+			Only:          []string{":fn"},
+			Module:        other,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "input argument",
+			Inputs:        []string{`"a"`},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "whole input",
+			Inputs:        []string{`["a", 1]`},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   true,
+		},
+		{
+			Name:          "other input",
+			Inputs:        []string{`"b"`},
+			Module:        mod,
+			Binding:       "fn",
+			MatchesModule: true,
+			MatchesCall:   false,
+		},
+	} {
+		example := example
+		t.Run(example.Name, func(t *testing.T) {
+			is := is.New(t)
+
+			filter, err := bass.ParseMemoFilter(example.Only, example.Inputs)
+			is.NoErr(err)
+
+			is.Equal(filter.MatchesModule(example.Module), example.MatchesModule)
+			is.Equal(filter.MatchesCall(example.Module, example.Binding, input), example.MatchesCall)
+		})
+	}
+}
+
+func TestResolveMemoCalls(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(dir, "mod.bass"), []byte(`
+(defn double [x] (* x 2))
+(defn triple [x] (* x 3))
+`), 0644))
+
+	mod := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Host: &bass.HostPath{
+				ContextDir: dir,
+				Path:       bass.ParseFileOrDirPath("mod.bass"),
+			},
+		},
+	}
+
+	bassLock := filepath.Join(dir, "bass.lock")
+	memos := bass.NewLockfileMemo(bassLock)
+	is.NoErr(memos.Store(mod, "double", bass.NewList(bass.Int(1)), bass.Int(2), 0))
+	is.NoErr(memos.Store(mod, "double", bass.NewList(bass.Int(2)), bass.Int(3), 0))
+	is.NoErr(memos.Store(mod, "triple", bass.NewList(bass.Int(1)), bass.Int(3), 0))
+
+	payload, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+
+	content := &proto.Memosphere{}
+	is.NoErr(prototext.Unmarshal(payload, content))
+
+	filter, err := bass.ParseMemoFilter([]string{"mod:double"}, nil)
+	is.NoErr(err)
+
+	calls, err := bass.MemoCalls(ctx, content, filter)
+	is.NoErr(err)
+	is.Equal(len(calls), 2)
+
+	outputs, err := bass.ResolveMemoCalls(ctx, calls, 1)
+	is.NoErr(err)
+	is.Equal(len(outputs), 2)
+
+	changes, err := bass.MemoChanges(calls, outputs)
+	is.NoErr(err)
+	is.Equal(len(changes), 1)
+	is.Equal(changes[0].Binding, bass.Symbol("double"))
+	basstest.Equal(t, changes[0].Input, bass.NewList(bass.Int(2)))
+	basstest.Equal(t, changes[0].Locked, bass.Int(3))
+	basstest.Equal(t, changes[0].Actual, bass.Int(4))
+}
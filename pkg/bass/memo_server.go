@@ -0,0 +1,247 @@
+package bass
+
+import (
+	"context"
+	"time"
+
+	"github.com/vito/bass/pkg/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MemoClientTimeout limits how long a MemoClient waits to connect to the memo
+// server and for each request.
+var MemoClientTimeout = 30 * time.Second
+
+// MemoClient is a Memos which stores memos on a memo server.
+type MemoClient struct {
+	Conn *grpc.ClientConn
+	proto.MemosClient
+}
+
+var _ Memos = &MemoClient{}
+
+// DialMemos connects to a memo server listening on the Unix socket at the
+// given path, waiting until the connection is established.
+func DialMemos(ctx context.Context, socketPath string) (*MemoClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, MemoClientTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		"unix://"+socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemoClient{
+		Conn:        conn,
+		MemosClient: proto.NewMemosClient(conn),
+	}, nil
+}
+
+func (client *MemoClient) Store(thunk Thunk, binding Symbol, input Value, output Value, ttl time.Duration) error {
+	key, err := memoKeyProto(thunk, binding, input)
+	if err != nil {
+		return err
+	}
+
+	op, err := MarshalProto(output)
+	if err != nil {
+		return err
+	}
+
+	req := &proto.StoreMemoRequest{
+		Key:    key,
+		Output: op,
+	}
+
+	if ttl > 0 {
+		req.Ttl = durationpb.New(ttl)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), MemoClientTimeout)
+	defer cancel()
+
+	_, err = client.MemosClient.Store(ctx, req)
+	return err
+}
+
+func (client *MemoClient) Retrieve(thunk Thunk, binding Symbol, input Value) (Value, bool, error) {
+	res, found, err := client.RetrieveResult(thunk, binding, input)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	val, err := FromProto(res.Output)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+func (client *MemoClient) RetrieveResult(thunk Thunk, binding Symbol, input Value) (*proto.Memosphere_Result, bool, error) {
+	key, err := memoKeyProto(thunk, binding, input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), MemoClientTimeout)
+	defer cancel()
+
+	res, err := client.MemosClient.Retrieve(ctx, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !res.Found {
+		return nil, false, nil
+	}
+
+	return &proto.Memosphere_Result{
+		Input:      key.Input,
+		Output:     res.Output,
+		RecordedAt: res.RecordedAt,
+		Ttl:        res.Ttl,
+	}, true, nil
+}
+
+func (client *MemoClient) Remove(thunk Thunk, binding Symbol, input Value) error {
+	key, err := memoKeyProto(thunk, binding, input)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), MemoClientTimeout)
+	defer cancel()
+
+	_, err = client.MemosClient.Remove(ctx, key)
+	return err
+}
+
+func (client *MemoClient) Close() error {
+	return client.Conn.Close()
+}
+
+// MemoServer serves Memos to memo clients.
+type MemoServer struct {
+	Memos Memos
+
+	proto.UnimplementedMemosServer
+}
+
+func (srv *MemoServer) Store(ctx context.Context, req *proto.StoreMemoRequest) (*emptypb.Empty, error) {
+	thunk, binding, input, err := memoKeyFromProto(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := FromProto(req.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.Memos.Store(thunk, binding, input, output, req.Ttl.AsDuration())
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (srv *MemoServer) Retrieve(ctx context.Context, key *proto.MemoKey) (*proto.RetrieveMemoResponse, error) {
+	thunk, binding, input, err := memoKeyFromProto(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if results, ok := srv.Memos.(MemoResults); ok {
+		res, found, err := results.RetrieveResult(thunk, binding, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return &proto.RetrieveMemoResponse{}, nil
+		}
+
+		return &proto.RetrieveMemoResponse{
+			Found:      true,
+			Output:     res.Output,
+			RecordedAt: res.RecordedAt,
+			Ttl:        res.Ttl,
+		}, nil
+	}
+
+	output, found, err := srv.Memos.Retrieve(thunk, binding, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return &proto.RetrieveMemoResponse{}, nil
+	}
+
+	op, err := MarshalProto(output)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RetrieveMemoResponse{
+		Found:  true,
+		Output: op,
+	}, nil
+}
+
+func (srv *MemoServer) Remove(ctx context.Context, key *proto.MemoKey) (*emptypb.Empty, error) {
+	thunk, binding, input, err := memoKeyFromProto(key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.Memos.Remove(thunk, binding, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func memoKeyProto(thunk Thunk, binding Symbol, input Value) (*proto.MemoKey, error) {
+	tp, err := thunk.Proto()
+	if err != nil {
+		return nil, err
+	}
+
+	ip, err := MarshalProto(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.MemoKey{
+		Module:  tp,
+		Binding: binding.String(),
+		Input:   ip,
+	}, nil
+}
+
+func memoKeyFromProto(key *proto.MemoKey) (Thunk, Symbol, Value, error) {
+	var thunk Thunk
+	err := thunk.UnmarshalProto(key.Module)
+	if err != nil {
+		return Thunk{}, "", nil, err
+	}
+
+	input, err := FromProto(key.Input)
+	if err != nil {
+		return Thunk{}, "", nil, err
+	}
+
+	return thunk, Symbol(key.Binding), input, nil
+}
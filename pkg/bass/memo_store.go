@@ -0,0 +1,327 @@
+package bass
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vito/bass/pkg/proto"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// MemoBackend constructs a Memos from the configured path.
+type MemoBackend func(ctx context.Context, path string) (Memos, error)
+
+// MemoBackends contains the backends which may be selected by MemosConfig.
+var MemoBackends = map[string]MemoBackend{
+	"dir": func(ctx context.Context, path string) (Memos, error) {
+		return NewDirMemos(path), nil
+	},
+	"json": func(ctx context.Context, path string) (Memos, error) {
+		return NewJSONMemo(path), nil
+	},
+	"socket": func(ctx context.Context, path string) (Memos, error) {
+		return DialMemos(ctx, path)
+	},
+}
+
+// MemoBackendNames returns the names of all memo backends in sorted order.
+func MemoBackendNames() []string {
+	names := make([]string, 0, len(MemoBackends))
+	for name := range MemoBackends {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewMemos initializes the memo backend selected by the config.
+//
+// The returned Memos may implement io.Closer, in which case it should be
+// closed when no longer needed.
+func NewMemos(ctx context.Context, config MemosConfig) (Memos, error) {
+	backend, found := MemoBackends[config.Backend]
+	if !found {
+		return nil, UnknownMemoBackendError{
+			Backend: config.Backend,
+		}
+	}
+
+	if config.Path == "" {
+		return nil, fmt.Errorf("memos: %s backend requires a path", config.Backend)
+	}
+
+	return backend(ctx, config.Path)
+}
+
+// UnknownMemoBackendError is returned when the configured memo backend does
+// not exist.
+type UnknownMemoBackendError struct {
+	Backend string
+}
+
+func (err UnknownMemoBackendError) Error() string {
+	return fmt.Sprintf(
+		"unknown memo backend: %s (supported: %s)",
+		err.Backend,
+		strings.Join(MemoBackendNames(), ", "),
+	)
+}
+
+// MemoResults is implemented by Memos which can retrieve the full result
+// recorded for an input, including when it was recorded and its TTL.
+type MemoResults interface {
+	// RetrieveResult returns the result recorded for the input, unless it has
+	// expired.
+	RetrieveResult(Thunk, Symbol, Value) (*proto.Memosphere_Result, bool, error)
+}
+
+var _ MemoResults = &Lockfile{}
+var _ MemoResults = &DirMemos{}
+var _ MemoResults = &MemoClient{}
+
+type memosKey struct{}
+
+// WithMemos sets a Memos to use alongside each lockfile on the host; see
+// LayeredMemos.
+func WithMemos(ctx context.Context, memos Memos) context.Context {
+	return context.WithValue(ctx, memosKey{}, memos)
+}
+
+func memosFromContext(ctx context.Context) Memos {
+	memos := ctx.Value(memosKey{})
+	if memos != nil {
+		return memos.(Memos)
+	}
+
+	return nil
+}
+
+// LayeredMemos stores memos in a lockfile and in a shared backend.
+//
+// Results are retrieved from the lockfile first, falling back to the shared
+// backend, so that a committed lockfile always takes precedence. Results found
+// in the shared backend are written back to the lockfile, so that it records
+// every result that was used. Results are stored to and removed from both.
+type LayeredMemos struct {
+	Lockfile *Lockfile
+	Shared   Memos
+}
+
+var _ Memos = &LayeredMemos{}
+
+func (memos *LayeredMemos) Store(thunk Thunk, binding Symbol, input Value, output Value, ttl time.Duration) error {
+	err := memos.Lockfile.Store(thunk, binding, input, output, ttl)
+	if err != nil {
+		return err
+	}
+
+	return memos.Shared.Store(thunk, binding, input, output, ttl)
+}
+
+func (memos *LayeredMemos) Retrieve(thunk Thunk, binding Symbol, input Value) (Value, bool, error) {
+	res, found, err := memos.Lockfile.Retrieve(thunk, binding, input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found {
+		return res, true, nil
+	}
+
+	if shared, ok := memos.Shared.(MemoResults); ok {
+		result, found, err := shared.RetrieveResult(thunk, binding, input)
+		if err != nil || !found {
+			return nil, false, err
+		}
+
+		err = memos.Lockfile.StoreResult(thunk, binding, input, result)
+		if err != nil {
+			return nil, false, err
+		}
+
+		val, err := FromProto(result.Output)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return val, true, nil
+	}
+
+	// the backend can't tell when the result expires, so it's recorded without
+	// a TTL
+	res, found, err = memos.Shared.Retrieve(thunk, binding, input)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	err = memos.Lockfile.Store(thunk, binding, input, res, 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return res, true, nil
+}
+
+func (memos *LayeredMemos) Remove(thunk Thunk, binding Symbol, input Value) error {
+	err := memos.Lockfile.Remove(thunk, binding, input)
+	if err != nil {
+		return err
+	}
+
+	return memos.Shared.Remove(thunk, binding, input)
+}
+
+// DirMemos stores memos in a directory, with a subdirectory for each module
+// and a file for each result, named by the digest of what they identify.
+type DirMemos struct {
+	Dir string
+}
+
+var _ Memos = &DirMemos{}
+
+// NewDirMemos returns a DirMemos which stores memos under the directory.
+func NewDirMemos(dir string) *DirMemos {
+	return &DirMemos{
+		Dir: dir,
+	}
+}
+
+func (memos *DirMemos) Store(thunk Thunk, binding Symbol, input Value, output Value, ttl time.Duration) error {
+	resultPath, ip, err := memos.resultPath(thunk, binding, input)
+	if err != nil {
+		return err
+	}
+
+	op, err := MarshalProto(output)
+	if err != nil {
+		return err
+	}
+
+	res := &proto.Memosphere_Result{
+		Input: ip,
+	}
+
+	UpdateMemoResult(res, op, ttl)
+
+	payload, err := gproto.Marshal(&proto.Memosphere_Call{
+		Binding: binding.String(),
+		Results: []*proto.Memosphere_Result{res},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(resultPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	// written atomically so that concurrent readers never see a partial result
+	return writeFileAtomic(resultPath, bytes.NewReader(payload))
+}
+
+func (memos *DirMemos) Retrieve(thunk Thunk, binding Symbol, input Value) (Value, bool, error) {
+	res, found, err := memos.RetrieveResult(thunk, binding, input)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	val, err := FromProto(res.Output)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+func (memos *DirMemos) RetrieveResult(thunk Thunk, binding Symbol, input Value) (*proto.Memosphere_Result, bool, error) {
+	resultPath, ip, err := memos.resultPath(thunk, binding, input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	payload, err := os.ReadFile(resultPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	call := &proto.Memosphere_Call{}
+	err = gproto.Unmarshal(payload, call)
+	if err != nil {
+		return nil, false, fmt.Errorf("unmarshal %s: %w", resultPath, err)
+	}
+
+	for _, res := range call.Results {
+		// guard against digest collisions
+		if call.Binding != binding.String() || !gproto.Equal(res.Input, ip) {
+			continue
+		}
+
+		if MemoExpired(res) {
+			return nil, false, nil
+		}
+
+		return res, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (memos *DirMemos) Remove(thunk Thunk, binding Symbol, input Value) error {
+	resultPath, _, err := memos.resultPath(thunk, binding, input)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(resultPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (memos *DirMemos) resultPath(thunk Thunk, binding Symbol, input Value) (string, *proto.Value, error) {
+	tp, err := thunk.Proto()
+	if err != nil {
+		return "", nil, err
+	}
+
+	mk, err := memoKey(tp)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ip, err := MarshalProto(input)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ik, err := memoKey(ip)
+	if err != nil {
+		return "", nil, err
+	}
+
+	moduleDigest := sha256.Sum256(mk)
+	resultDigest := sha256.Sum256([]byte(memoResultKey(mk, binding.String(), ik)))
+
+	return filepath.Join(
+		memos.Dir,
+		hex.EncodeToString(moduleDigest[:]),
+		hex.EncodeToString(resultDigest[:]),
+	), ip, nil
+}
@@ -0,0 +1,145 @@
+package bass_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/basstest"
+	"github.com/vito/bass/pkg/proto"
+	"github.com/vito/is"
+	"google.golang.org/grpc"
+)
+
+func TestDirMemos(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "memos")
+	testRW(t, bass.NewDirMemos(dir), dir)
+}
+
+func TestJSONMemo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memos.json")
+	testRW(t, bass.NewJSONMemo(path), path)
+}
+
+func TestMemoServer(t *testing.T) {
+	is := is.New(t)
+
+	dir := filepath.Join(t.TempDir(), "memos")
+	socketPath := filepath.Join(t.TempDir(), "memos.sock")
+
+	l, err := net.Listen("unix", socketPath)
+	is.NoErr(err)
+
+	srv := grpc.NewServer()
+	proto.RegisterMemosServer(srv, &bass.MemoServer{
+		Memos: bass.NewDirMemos(dir),
+	})
+
+	go srv.Serve(l)
+	defer srv.Stop()
+
+	client, err := bass.NewMemos(context.Background(), bass.MemosConfig{
+		Backend: "socket",
+		Path:    socketPath,
+	})
+	is.NoErr(err)
+
+	testRW(t, client, dir)
+
+	// the recorded time and TTL are passed along
+	thunk := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+	is.NoErr(client.Store(thunk, "ttl", bass.String("x"), bass.Int(1), time.Hour))
+
+	res, found, err := client.(bass.MemoResults).RetrieveResult(thunk, "ttl", bass.String("x"))
+	is.NoErr(err)
+	is.True(found)
+	is.Equal(res.Ttl.AsDuration(), time.Hour)
+	is.True(res.RecordedAt != nil)
+}
+
+func TestNewMemos(t *testing.T) {
+	is := is.New(t)
+
+	_, err := bass.NewMemos(context.Background(), bass.MemosConfig{
+		Backend: "bogus",
+		Path:    "/tmp/memos",
+	})
+	is.Equal(err, bass.UnknownMemoBackendError{Backend: "bogus"})
+}
+
+func TestOpenMemosShared(t *testing.T) {
+	is := is.New(t)
+
+	shared := bass.NewDirMemos(t.TempDir())
+	ctx := bass.WithMemos(context.Background(), shared)
+
+	dir := t.TempDir()
+	bassLock := filepath.Join(dir, "test.lock")
+	lockfile := bass.NewLockfileMemo(bassLock)
+
+	fp := bass.NewHostPath(dir, bass.ParseFileOrDirPath("./test.lock"))
+	memos, err := bass.OpenMemos(ctx, fp)
+	is.NoErr(err)
+	layered, ok := memos.(*bass.LayeredMemos)
+	is.True(ok)
+	is.Equal(layered.Shared, shared)
+
+	thunk := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+
+	// the lockfile takes precedence over the shared backend
+	is.NoErr(lockfile.Store(thunk, "a", bass.String("x"), bass.Int(1), 0))
+	is.NoErr(shared.Store(thunk, "a", bass.String("x"), bass.Int(2), 0))
+
+	res, found, err := memos.Retrieve(thunk, "a", bass.String("x"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	// results missing from the lockfile fall back to the shared backend
+	is.NoErr(shared.Store(thunk, "a", bass.String("y"), bass.Int(3), 0))
+
+	res, found, err = memos.Retrieve(thunk, "a", bass.String("y"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(3))
+
+	// results found in the shared backend are written back to the lockfile,
+	// keeping their TTL
+	res, found, err = lockfile.Retrieve(thunk, "a", bass.String("y"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(3))
+
+	is.NoErr(shared.Store(thunk, "a", bass.String("w"), bass.Int(5), time.Hour))
+
+	res, found, err = memos.Retrieve(thunk, "a", bass.String("w"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(5))
+
+	sharedRes, found, err := shared.RetrieveResult(thunk, "a", bass.String("w"))
+	is.NoErr(err)
+	is.True(found)
+
+	lockRes, found, err := lockfile.RetrieveResult(thunk, "a", bass.String("w"))
+	is.NoErr(err)
+	is.True(found)
+	is.Equal(lockRes.Ttl.AsDuration(), time.Hour)
+	is.True(lockRes.RecordedAt.AsTime().Equal(sharedRes.RecordedAt.AsTime()))
+
+	// results are stored to both
+	is.NoErr(memos.Store(thunk, "a", bass.String("z"), bass.Int(4), 0))
+
+	res, found, err = lockfile.Retrieve(thunk, "a", bass.String("z"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(4))
+
+	res, found, err = shared.Retrieve(thunk, "a", bass.String("z"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(4))
+}
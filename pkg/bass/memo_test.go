@@ -2,6 +2,8 @@ package bass_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -9,11 +11,15 @@ import (
 	"testing/fstest"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/vito/bass/pkg/bass"
 	"github.com/vito/bass/pkg/basstest"
+	"github.com/vito/bass/pkg/proto"
 	"github.com/vito/bass/pkg/runtimes"
 	"github.com/vito/is"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/prototext"
+	gproto "google.golang.org/protobuf/proto"
 )
 
 func TestOpenMemosHostPath(t *testing.T) {
@@ -106,7 +112,7 @@ func TestOpenMemosThunkPath(t *testing.T) {
 			}, fstest.MapFS{
 				"foo/named.lock": {
 					Data: genLockfile(t, func(m bass.Memos) error {
-						return m.Store(thunk, "bnd", bass.String("a"), bass.Int(1))
+						return m.Store(thunk, "bnd", bass.String("a"), bass.Int(1), 0)
 					}),
 					Mode: 0644,
 				},
@@ -125,7 +131,7 @@ func TestOpenMemosThunkPath(t *testing.T) {
 		basstest.Equal(t, res, bass.Int(1))
 
 		// noop
-		err = memos.Store(thunk, "bnd", bass.String("b"), bass.Int(2))
+		err = memos.Store(thunk, "bnd", bass.String("b"), bass.Int(2), 0)
 		is.NoErr(err)
 
 		// can't find previous writes
@@ -164,7 +170,7 @@ func TestLockfileMemoConcurrentWrites(t *testing.T) {
 
 		eg.Go(func() error {
 			sym := bass.String(strconv.Itoa(num))
-			return memos.Store(thunk, "bnd", sym, bass.Int(num))
+			return memos.Store(thunk, "bnd", sym, bass.Int(num), 0)
 		})
 	}
 
@@ -191,11 +197,11 @@ func testRW(t *testing.T, memos bass.Memos, bassLock string) {
 	is.True(!found)
 
 	// set values
-	err = memos.Store(thunk1, "bnd", bass.String("a"), bass.Int(1))
+	err = memos.Store(thunk1, "bnd", bass.String("a"), bass.Int(1), 0)
 	is.NoErr(err)
-	err = memos.Store(thunk1, "bnd", bass.String("b"), bass.Int(2))
+	err = memos.Store(thunk1, "bnd", bass.String("b"), bass.Int(2), 0)
 	is.NoErr(err)
-	err = memos.Store(thunk2, "bnd", bass.String("a"), bass.String("one"))
+	err = memos.Store(thunk2, "bnd", bass.String("a"), bass.String("one"), 0)
 	is.NoErr(err)
 
 	// file now exists
@@ -235,3 +241,412 @@ func testRW(t *testing.T, memos bass.Memos, bassLock string) {
 	is.True(found)
 	basstest.Equal(t, res, bass.String("one"))
 }
+
+func TestLockfileMemoCanonicalOrder(t *testing.T) {
+	is := is.New(t)
+
+	thunk1 := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+	thunk2 := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"bar"}}}
+
+	forward := genLockfile(t, func(m bass.Memos) error {
+		is.NoErr(m.Store(thunk1, "a", bass.String("x"), bass.Int(1), 0))
+		is.NoErr(m.Store(thunk1, "a", bass.String("y"), bass.Int(2), 0))
+		is.NoErr(m.Store(thunk1, "b", bass.String("x"), bass.Int(3), 0))
+		return m.Store(thunk2, "a", bass.String("x"), bass.Int(4), 0)
+	})
+
+	backward := genLockfile(t, func(m bass.Memos) error {
+		is.NoErr(m.Store(thunk2, "a", bass.String("x"), bass.Int(4), 0))
+		is.NoErr(m.Store(thunk1, "b", bass.String("x"), bass.Int(3), 0))
+		is.NoErr(m.Store(thunk1, "a", bass.String("y"), bass.Int(2), 0))
+		return m.Store(thunk1, "a", bass.String("x"), bass.Int(1), 0)
+	})
+
+	is.Equal(string(forward), string(backward))
+}
+
+func TestMergeMemos(t *testing.T) {
+	thunk := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+
+	type result struct {
+		input  string
+		output int
+	}
+
+	gen := func(t *testing.T, results ...result) *proto.Memosphere {
+		is := is.New(t)
+
+		if len(results) == 0 {
+			return &proto.Memosphere{}
+		}
+
+		lock := genLockfile(t, func(m bass.Memos) error {
+			for _, res := range results {
+				err := m.Store(thunk, "bnd", bass.String(res.input), bass.Int(res.output), 0)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		content := &proto.Memosphere{}
+		is.NoErr(prototext.Unmarshal(lock, content))
+		return content
+	}
+
+	for _, example := range []struct {
+		Name      string
+		Base      []result
+		Ours      []result
+		Theirs    []result
+		Merged    []result
+		Conflicts []string
+	}{
+		{
+			Name:   "unchanged",
+			Base:   []result{{"a", 1}},
+			Ours:   []result{{"a", 1}},
+			Theirs: []result{{"a", 1}},
+			Merged: []result{{"a", 1}},
+		},
+		{
+			Name:   "added on both sides",
+			Base:   []result{{"a", 1}},
+			Ours:   []result{{"a", 1}, {"b", 2}},
+			Theirs: []result{{"a", 1}, {"c", 3}},
+			Merged: []result{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+		{
+			Name:   "changed on one side",
+			Base:   []result{{"a", 1}, {"b", 2}},
+			Ours:   []result{{"a", 10}, {"b", 2}},
+			Theirs: []result{{"a", 1}, {"b", 20}},
+			Merged: []result{{"a", 10}, {"b", 20}},
+		},
+		{
+			Name:   "changed identically",
+			Base:   []result{{"a", 1}},
+			Ours:   []result{{"a", 10}},
+			Theirs: []result{{"a", 10}},
+			Merged: []result{{"a", 10}},
+		},
+		{
+			Name:   "removed on one side",
+			Base:   []result{{"a", 1}, {"b", 2}},
+			Ours:   []result{{"b", 2}},
+			Theirs: []result{{"a", 1}, {"b", 2}},
+			Merged: []result{{"b", 2}},
+		},
+		{
+			Name:      "changed on both sides",
+			Base:      []result{{"a", 1}},
+			Ours:      []result{{"a", 10}},
+			Theirs:    []result{{"a", 100}},
+			Merged:    []result{{"a", 10}},
+			Conflicts: []string{"a"},
+		},
+		{
+			Name:      "added differently on both sides",
+			Ours:      []result{{"a", 10}},
+			Theirs:    []result{{"a", 100}},
+			Merged:    []result{{"a", 10}},
+			Conflicts: []string{"a"},
+		},
+		{
+			Name:      "changed and removed",
+			Base:      []result{{"a", 1}, {"b", 2}},
+			Ours:      []result{{"b", 2}},
+			Theirs:    []result{{"a", 100}, {"b", 2}},
+			Merged:    []result{{"b", 2}},
+			Conflicts: []string{"a"},
+		},
+	} {
+		example := example
+		t.Run(example.Name, func(t *testing.T) {
+			is := is.New(t)
+
+			merged, conflicts, err := bass.MergeMemos(
+				gen(t, example.Base...),
+				gen(t, example.Ours...),
+				gen(t, example.Theirs...),
+			)
+			is.NoErr(err)
+
+			expected := gen(t, example.Merged...)
+			is.True(gproto.Equal(merged, expected))
+
+			is.Equal(len(conflicts), len(example.Conflicts))
+			for i, input := range example.Conflicts {
+				val, err := bass.FromProto(conflicts[i].Input)
+				is.NoErr(err)
+				basstest.Equal(t, val, bass.String(input))
+			}
+		})
+	}
+}
+
+func TestLockfileMemoTTL(t *testing.T) {
+	is := is.New(t)
+
+	clock := clockwork.NewFakeClock()
+	defer func(orig clockwork.Clock) { bass.Clock = orig }(bass.Clock)
+	bass.Clock = clock
+
+	dir := t.TempDir()
+	bassLock := filepath.Join(dir, "test.lock")
+
+	memos := bass.NewLockfileMemo(bassLock)
+
+	thunk := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("a"), bass.Int(1), time.Hour))
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("b"), bass.Int(2), 0))
+
+	lockContent, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+
+	content := &proto.Memosphere{}
+	is.NoErr(prototext.Unmarshal(lockContent, content))
+
+	results := content.Memos[0].Calls[0].Results
+	is.Equal(len(results), 2)
+
+	// every result records when it was recorded
+	is.True(results[0].RecordedAt.AsTime().Equal(clock.Now().Truncate(time.Second)))
+	is.True(results[1].RecordedAt.AsTime().Equal(clock.Now().Truncate(time.Second)))
+
+	readonly := bass.ReadonlyMemos{Content: content}
+
+	clock.Advance(time.Hour - time.Second)
+
+	res, found, err := memos.Retrieve(thunk, "bnd", bass.String("a"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	clock.Advance(time.Second)
+
+	// expired; treated as a miss
+	_, found, err = memos.Retrieve(thunk, "bnd", bass.String("a"))
+	is.NoErr(err)
+	is.True(!found)
+
+	// no TTL; never expires
+	res, found, err = memos.Retrieve(thunk, "bnd", bass.String("b"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(2))
+
+	// read-only memos can't be refreshed, so the expired value is returned
+	res, found, err = readonly.Retrieve(thunk, "bnd", bass.String("a"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	// refreshing an expired result resets the timer, even if it's unchanged
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("a"), bass.Int(1), time.Hour))
+
+	res, found, err = memos.Retrieve(thunk, "bnd", bass.String("a"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	// storing an unchanged result leaves the lock file as-is
+	clock.Advance(time.Minute)
+
+	before, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("a"), bass.Int(1), time.Hour))
+
+	after, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+	is.Equal(string(after), string(before))
+
+	// storing a changed result resets the timer
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("a"), bass.Int(10), time.Hour))
+
+	clock.Advance(time.Hour - time.Second)
+
+	res, found, err = memos.Retrieve(thunk, "bnd", bass.String("a"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(10))
+
+	// results without a TTL keep their recorded time until they change
+	recorded := clock.Now().Truncate(time.Second)
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("c"), bass.Int(3), 0))
+
+	clock.Advance(time.Hour)
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("c"), bass.Int(3), 0))
+
+	result := func(input bass.Value) *proto.Memosphere_Result {
+		lockContent, err := os.ReadFile(bassLock)
+		is.NoErr(err)
+
+		content := &proto.Memosphere{}
+		is.NoErr(prototext.Unmarshal(lockContent, content))
+
+		inp, err := bass.MarshalProto(input)
+		is.NoErr(err)
+
+		for _, res := range content.Memos[0].Calls[0].Results {
+			if gproto.Equal(res.Input, inp) {
+				return res
+			}
+		}
+
+		t.Fatalf("no result for %s", input)
+		return nil
+	}
+
+	is.True(result(bass.String("c")).RecordedAt.AsTime().Equal(recorded))
+
+	is.NoErr(memos.Store(thunk, "bnd", bass.String("c"), bass.Int(30), 0))
+	is.True(result(bass.String("c")).RecordedAt.AsTime().Equal(clock.Now().Truncate(time.Second)))
+}
+
+func TestParseMemoTTL(t *testing.T) {
+	for _, example := range []struct {
+		TTL      bass.Value
+		Duration time.Duration
+		Err      bool
+	}{
+		{nil, 0, false},
+		{bass.Int(86400), 24 * time.Hour, false},
+		{bass.String("day"), 24 * time.Hour, false},
+		{bass.String("week"), 7 * 24 * time.Hour, false},
+		{bass.String("36h"), 36 * time.Hour, false},
+		{bass.String("fortnight"), 0, true},
+		{bass.Int(-1), 0, true},
+		{bass.Bool(true), 0, true},
+	} {
+		example := example
+		t.Run(fmt.Sprintf("%v", example.TTL), func(t *testing.T) {
+			is := is.New(t)
+
+			ttl, err := bass.ParseMemoTTL(example.TTL)
+			if example.Err {
+				is.True(err != nil)
+			} else {
+				is.NoErr(err)
+				is.Equal(ttl, example.Duration)
+			}
+		})
+	}
+}
+
+func TestMemoRecorderPrune(t *testing.T) {
+	is := is.New(t)
+
+	ctx, rec := bass.RecordMemos(context.Background())
+
+	dir := t.TempDir()
+	bassLock := filepath.Join(dir, "test.lock")
+
+	memos := bass.NewLockfileMemo(bassLock)
+
+	thunk1 := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+	thunk2 := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"bar"}}}
+
+	is.NoErr(memos.Store(thunk1, "a", bass.String("x"), bass.Int(1), 0))
+	is.NoErr(memos.Store(thunk1, "a", bass.String("y"), bass.Int(2), 0))
+	is.NoErr(memos.Store(thunk1, "b", bass.String("x"), bass.Int(3), 0))
+	is.NoErr(memos.Store(thunk2, "a", bass.String("x"), bass.Int(4), 0))
+
+	var recall bass.Applicative
+	is.NoErr(bass.Ground.GetDecode("recall-memo", &recall))
+
+	fp := bass.NewHostPath(dir, bass.ParseFileOrDirPath("./test.lock"))
+	res, err := bass.Trampoline(ctx, recall.Unwrap().Call(ctx, bass.NewList(
+		fp,
+		thunk1,
+		bass.Symbol("a"),
+		bass.String("x"),
+	), bass.NewEmptyScope(), bass.Identity))
+	is.NoErr(err)
+	basstest.Equal(t, res, bass.Int(1))
+
+	lockContent, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+
+	content := &proto.Memosphere{}
+	is.NoErr(prototext.Unmarshal(lockContent, content))
+
+	pruned, err := rec.Prune(bassLock, content)
+	is.NoErr(err)
+	is.Equal(pruned, 3)
+
+	pruneMemos := bass.ReadonlyMemos{Content: content}
+
+	res, found, err := pruneMemos.Retrieve(thunk1, "a", bass.String("x"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	is.Equal(len(content.Memos), 1)
+	is.Equal(len(content.Memos[0].Calls), 1)
+	is.Equal(len(content.Memos[0].Calls[0].Results), 1)
+
+	// refuses to prune lockfiles which nothing was recalled from, rather than
+	// pruning everything
+	pruned, err = rec.Prune(filepath.Join(dir, "other.lock"), content)
+	var nothingRecalled bass.NothingRecalledError
+	is.True(errors.As(err, &nothingRecalled))
+	is.Equal(pruned, 0)
+	is.Equal(len(content.Memos), 1)
+}
+
+func TestLockfilePrune(t *testing.T) {
+	is := is.New(t)
+
+	ctx, rec := bass.RecordMemos(context.Background())
+
+	dir := t.TempDir()
+	bassLock := filepath.Join(dir, "test.lock")
+
+	memos := bass.NewLockfileMemo(bassLock)
+
+	thunk := bass.Thunk{Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"foo"}}}
+	is.NoErr(memos.Store(thunk, "a", bass.String("x"), bass.Int(1), 0))
+	is.NoErr(memos.Store(thunk, "a", bass.String("y"), bass.Int(2), 0))
+
+	before, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+
+	// nothing recalled yet; the lockfile must be left alone
+	_, err = memos.Prune(rec)
+	var nothingRecalled bass.NothingRecalledError
+	is.True(errors.As(err, &nothingRecalled))
+
+	after, err := os.ReadFile(bassLock)
+	is.NoErr(err)
+	is.Equal(string(after), string(before))
+
+	var recall bass.Applicative
+	is.NoErr(bass.Ground.GetDecode("recall-memo", &recall))
+
+	fp := bass.NewHostPath(dir, bass.ParseFileOrDirPath("./test.lock"))
+	_, err = bass.Trampoline(ctx, recall.Unwrap().Call(ctx, bass.NewList(
+		fp,
+		thunk,
+		bass.Symbol("a"),
+		bass.String("x"),
+	), bass.NewEmptyScope(), bass.Identity))
+	is.NoErr(err)
+
+	pruned, err := memos.Prune(rec)
+	is.NoErr(err)
+	is.Equal(pruned, 1)
+
+	res, found, err := memos.Retrieve(thunk, "a", bass.String("x"))
+	is.NoErr(err)
+	is.True(found)
+	basstest.Equal(t, res, bass.Int(1))
+
+	_, found, err = memos.Retrieve(thunk, "a", bass.String("y"))
+	is.NoErr(err)
+	is.True(!found)
+}
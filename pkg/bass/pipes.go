@@ -77,6 +77,18 @@ func NewJSONSink(name string, out io.Writer) *JSONSink {
 	}
 }
 
+// NewIndentedJSONSink returns a JSONSink which writes each value as
+// indented, multi-line JSON.
+func NewIndentedJSONSink(name string, out io.Writer) *JSONSink {
+	enc := NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return &JSONSink{
+		Name: name,
+		enc:  enc,
+	}
+}
+
 func (sink *JSONSink) String() string {
 	return sink.Name
 }
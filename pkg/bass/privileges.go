@@ -0,0 +1,158 @@
+package bass
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Capabilities contains the names of the Linux capabilities which may be
+// granted to a thunk, in order of their capability number.
+//
+// Names are lowercase and hyphenated without the CAP_ prefix, e.g.
+// CAP_NET_ADMIN is "net-admin".
+var Capabilities = []string{
+	"chown",
+	"dac-override",
+	"dac-read-search",
+	"fowner",
+	"fsetid",
+	"kill",
+	"setgid",
+	"setuid",
+	"setpcap",
+	"linux-immutable",
+	"net-bind-service",
+	"net-broadcast",
+	"net-admin",
+	"net-raw",
+	"ipc-lock",
+	"ipc-owner",
+	"sys-module",
+	"sys-rawio",
+	"sys-chroot",
+	"sys-ptrace",
+	"sys-pacct",
+	"sys-admin",
+	"sys-boot",
+	"sys-nice",
+	"sys-resource",
+	"sys-time",
+	"sys-tty-config",
+	"mknod",
+	"lease",
+	"audit-write",
+	"audit-control",
+	"setfcap",
+	"mac-override",
+	"mac-admin",
+	"syslog",
+	"wake-alarm",
+	"block-suspend",
+	"audit-read",
+	"perfmon",
+	"bpf",
+	"checkpoint-restore",
+}
+
+// ValidateCapability returns an error if the name is not a known capability.
+func ValidateCapability(name string) error {
+	for _, c := range Capabilities {
+		if c == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown capability: %s", name)
+}
+
+// ValidateDevice returns an error if the path is not a clean absolute path
+// under /dev/.
+func ValidateDevice(dev string) error {
+	if path.Clean(dev) != dev || !strings.HasPrefix(dev, "/dev/") {
+		return fmt.Errorf("invalid device path: %s (must be under /dev/)", dev)
+	}
+
+	return nil
+}
+
+type privilegesKey struct{}
+
+// WithPrivileges sets the policy for which capabilities and devices scripts
+// may request.
+func WithPrivileges(ctx context.Context, config PrivilegesConfig) context.Context {
+	return context.WithValue(ctx, privilegesKey{}, config)
+}
+
+// PrivilegesFromContext returns the policy set by WithPrivileges, or an empty
+// policy which allows nothing.
+func PrivilegesFromContext(ctx context.Context) PrivilegesConfig {
+	config, _ := ctx.Value(privilegesKey{}).(PrivilegesConfig)
+	return config
+}
+
+// CheckCapability returns an error if the capability is not allowed.
+func (config PrivilegesConfig) CheckCapability(name string) error {
+	for _, c := range config.Capabilities {
+		if c == name {
+			return nil
+		}
+	}
+
+	return PrivilegeNotAllowedError{
+		Kind: "capability",
+		Name: name,
+		Key:  "capabilities",
+	}
+}
+
+// CheckDevice returns an error if the device is not allowed.
+func (config PrivilegesConfig) CheckDevice(dev string) error {
+	for _, d := range config.Devices {
+		if d == dev {
+			return nil
+		}
+	}
+
+	return PrivilegeNotAllowedError{
+		Kind: "device",
+		Name: dev,
+		Key:  "devices",
+	}
+}
+
+// CheckThunk returns an error if the thunk requests any capability or device
+// which is not allowed.
+func (config PrivilegesConfig) CheckThunk(thunk Thunk) error {
+	for _, c := range thunk.Capabilities {
+		if err := config.CheckCapability(c); err != nil {
+			return err
+		}
+	}
+
+	for _, dev := range thunk.Devices {
+		if err := config.CheckDevice(dev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PrivilegeNotAllowedError is returned when a script requests a capability or
+// device which is not allowlisted in the config.
+type PrivilegeNotAllowedError struct {
+	Kind string
+	Name string
+	Key  string
+}
+
+func (err PrivilegeNotAllowedError) Error() string {
+	return fmt.Sprintf(
+		"%s not allowed: %s (add it to privileges.%s in config.json)",
+		err.Kind,
+		err.Name,
+		err.Key,
+	)
+}
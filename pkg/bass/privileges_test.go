@@ -0,0 +1,38 @@
+package bass_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/is"
+)
+
+func TestPrivilegesConfigCheckThunk(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"run"},
+		},
+		Capabilities: []string{"net-admin"},
+		Devices:      []string{"/dev/fuse"},
+	}
+
+	var notAllowed bass.PrivilegeNotAllowedError
+	err := bass.PrivilegesConfig{}.CheckThunk(thunk)
+	is.True(errors.As(err, &notAllowed))
+	is.Equal(notAllowed.Name, "net-admin")
+
+	err = bass.PrivilegesConfig{
+		Capabilities: []string{"net-admin"},
+	}.CheckThunk(thunk)
+	is.True(errors.As(err, &notAllowed))
+	is.Equal(notAllowed.Name, "/dev/fuse")
+
+	err = bass.PrivilegesConfig{
+		Capabilities: []string{"net-admin"},
+		Devices:      []string{"/dev/fuse"},
+	}.CheckThunk(thunk)
+	is.NoErr(err)
+}
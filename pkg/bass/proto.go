@@ -296,6 +296,13 @@ func (value *FSPath) MarshalProto() (proto.Message, error) {
 func (value Thunk) MarshalProto() (proto.Message, error) {
 	thunk := &proto.Thunk{
 		Insecure: value.Insecure,
+		ImageEnv: value.ImageEnv,
+		User:     value.User,
+		Network:  value.Network,
+
+		Capabilities: value.Capabilities,
+		Devices:      value.Devices,
+		Ssh:          value.SSH,
 	}
 
 	if value.Image != nil {
@@ -386,6 +393,24 @@ func (value Thunk) MarshalProto() (proto.Message, error) {
 		}
 	}
 
+	if value.ImageConfig != nil {
+		ic, err := value.ImageConfig.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("image config: %w", err)
+		}
+
+		thunk.ImageConfig = ic.(*proto.ThunkImageConfig)
+	}
+
+	if value.Limits != nil {
+		l, err := value.Limits.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("limits: %w", err)
+		}
+
+		thunk.Limits = l.(*proto.ThunkLimits)
+	}
+
 	return thunk, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 )
 
 // Protocol determines how response data is parsed from a thunk's response.
@@ -22,17 +23,51 @@ type WriteFlusher interface {
 	Flush() error
 }
 
-// Protocols defines the set of supported protocols for reading responses.
+// Protocols defines the set of built-in protocols for reading responses.
+//
+// Additional protocols may be added with RegisterProtocol.
 var Protocols = map[Symbol]Protocol{
 	"raw":        RawProtocol{},
 	"json":       JSONProtocol{},
 	"unix-table": UnixTableProtocol{},
 }
 
+// registeredProtocols contains the protocols added with RegisterProtocol.
+var registeredProtocols = map[Symbol]Protocol{}
+var protocolsL sync.RWMutex
+
+// RegisterProtocol adds or replaces the named protocol.
+//
+// Built-in protocols cannot be replaced.
+func RegisterProtocol(name Symbol, proto Protocol) error {
+	if _, found := Protocols[name]; found {
+		return BuiltinProtocolError{name}
+	}
+
+	protocolsL.Lock()
+	registeredProtocols[name] = proto
+	protocolsL.Unlock()
+
+	return nil
+}
+
+// UnregisterProtocol removes a protocol added with RegisterProtocol.
+func UnregisterProtocol(name Symbol) {
+	protocolsL.Lock()
+	delete(registeredProtocols, name)
+	protocolsL.Unlock()
+}
+
 // DecodeProto uses the named protocol to decode values from r into the
 // sink.
 func DecodeProto(ctx context.Context, name Symbol, sink PipeSink, r io.Reader) error {
 	proto, found := Protocols[name]
+	if !found {
+		protocolsL.RLock()
+		proto, found = registeredProtocols[name]
+		protocolsL.RUnlock()
+	}
+
 	if !found {
 		return UnknownProtocolError{name}
 	}
@@ -50,6 +85,16 @@ func (err UnknownProtocolError) Error() string {
 	return fmt.Sprintf("unknown protocol: %s", err.Protocol)
 }
 
+// BuiltinProtocolError is returned when attempting to replace a built-in
+// protocol.
+type BuiltinProtocolError struct {
+	Protocol Symbol
+}
+
+func (err BuiltinProtocolError) Error() string {
+	return fmt.Sprintf("cannot redefine built-in protocol: %s", err.Protocol)
+}
+
 // UnixTableProtocol parses lines of tabular output with columns separated by
 // whitespace.
 //
@@ -124,3 +169,28 @@ func (RawProtocol) DecodeInto(ctx context.Context, sink PipeSink, r io.Reader) e
 
 	return sink.Emit(String(buf.String()))
 }
+
+// CombinerProtocol is a protocol implemented in Bass.
+//
+// The combiner is called with a source yielding the full content as a single
+// string, as with RawProtocol, and the sink to emit decoded values to.
+type CombinerProtocol struct {
+	Combiner Combiner
+}
+
+var _ Protocol = CombinerProtocol{}
+
+// DecodeInto reads the full content from r and passes it along to the
+// combiner along with the sink.
+func (proto CombinerProtocol) DecodeInto(ctx context.Context, sink PipeSink, r io.Reader) error {
+	raw := NewInMemorySink()
+	err := RawProtocol{}.DecodeInto(ctx, raw, r)
+	if err != nil {
+		return err
+	}
+
+	args := NewList(NewSource(raw.Source()), NewSink(sink))
+
+	_, err = Trampoline(ctx, proto.Combiner.Call(ctx, args, NewEmptyScope(), Identity))
+	return err
+}
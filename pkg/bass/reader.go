@@ -171,6 +171,12 @@ func readSymbol(rd *slurpreader.Reader, init rune) (slurpcore.Any, error) {
 		return predefVal, nil
 	}
 
+	// keywords may contain slashes, e.g. :linux/amd64, since evaluating a
+	// keyword can only ever yield a symbol, not a path
+	if strings.HasPrefix(s, ":") && !strings.Contains(s[1:], ":") {
+		return Keyword(s[1:]), nil
+	}
+
 	pathSegments := strings.Split(s, "/")
 	if len(pathSegments) > 1 {
 		path, err := readPath(pathSegments)
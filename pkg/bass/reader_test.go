@@ -54,6 +54,11 @@ func TestReader(t *testing.T) {
 			Result: bass.Keyword("foo-bar"),
 		},
 
+		{
+			Source: ":linux/amd64",
+			Result: bass.Keyword("linux/amd64"),
+		},
+
 		{
 			Source: `"hello world"`,
 			Result: bass.String("hello world"),
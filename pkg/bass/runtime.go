@@ -18,7 +18,9 @@ type Runtime interface {
 	Read(context.Context, io.Writer, Thunk) error
 	Export(context.Context, io.Writer, Thunk) error
 	ExportPath(context.Context, io.Writer, ThunkPath) error
+	Publish(context.Context, ThunkImageRef, Thunk) (ThunkImageRef, error)
 	Prune(context.Context, PruneOpts) error
+	DiskUsage(context.Context) ([]DiskUsage, error)
 	Close() error
 }
 
@@ -36,6 +38,20 @@ type PruneOpts struct {
 	KeepBytes int64
 }
 
+// DiskUsage is a record of disk space used by a cache.
+type DiskUsage struct {
+	ID          string
+	Description string
+	Size        int64
+
+	// UsageCount is the number of times the record has been used. It is zero
+	// if unknown.
+	UsageCount int
+
+	// LastUsedAt is the last time the record was used. It is nil if unknown.
+	LastUsedAt *time.Time
+}
+
 type poolKey struct{}
 
 func WithRuntimePool(ctx context.Context, pool RuntimePool) context.Context {
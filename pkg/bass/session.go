@@ -71,6 +71,10 @@ func (runtime *Session) Load(ctx context.Context, thunk Thunk) (*Scope, error) {
 }
 
 func (runtime *Session) run(ctx context.Context, thunk Thunk, runMain bool, w io.Writer) (*Scope, error) {
+	if len(thunk.Capabilities) > 0 || len(thunk.Devices) > 0 {
+		return nil, fmt.Errorf("capabilities and devices are not supported for bass thunks: %s", thunk)
+	}
+
 	var module *Scope
 
 	state := RunState{
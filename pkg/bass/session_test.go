@@ -3,6 +3,7 @@ package bass_test
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/vito/bass/pkg/bass"
@@ -114,3 +115,20 @@ func TestInternalBindings(t *testing.T) {
 		t.Run(example.Name, example.Run)
 	}
 }
+
+func TestBassPrivileges(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"run"},
+		},
+	}
+
+	thunk, err := thunk.WithDevice("/dev/fuse")
+	is.NoErr(err)
+
+	err = bass.NewBass().Run(context.Background(), thunk)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "not supported for bass thunks"))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/vito/invaders"
 	"google.golang.org/protobuf/encoding/protojson"
 	gproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type Thunk struct {
@@ -68,8 +69,79 @@ type Thunk struct {
 	// e.g. one minute. Doing so prevents the first call from being cached
 	// forever while still allowing some level of caching to take place.
 	Labels *Scope `json:"labels,omitempty"`
+
+	// ImageConfig configures the OCI image config used when the thunk is
+	// exported as an image, e.g. its entrypoint and default command.
+	//
+	// It does not influence caching; see SHA256.
+	ImageConfig *ThunkImageConfig `json:"image_config,omitempty"`
+
+	// ImageEnv may be set to true to run the command the way the OCI image
+	// is configured to run it: as the image's user and through the image's
+	// entrypoint.
+	//
+	// The image's env vars are always inherited, with the thunk's Env taking
+	// precedence.
+	//
+	// The image's working directory is not honored. Commands run in the
+	// runtime's working directory so that thunk paths in args, stdin, and env
+	// resolve relative to it, and so that files written to the working
+	// directory become the thunk's output. Use Dir with an absolute path to
+	// run in the image's working directory instead.
+	ImageEnv bool `json:"image_env,omitempty"`
+
+	// User is the user to run the command as, in the form user[:group]. The
+	// user and group may each be a name or a numeric ID.
+	//
+	// If unset, the command runs as root, or as the image's user if ImageEnv
+	// is set.
+	User string `json:"user,omitempty"`
+
+	// Limits configures resource limits for the command. Runtimes enforce them
+	// on a best-effort basis, and must warn about any they can't enforce.
+	Limits *ThunkLimits `json:"limits,omitempty"`
+
+	// Network configures the command's network access; see NetworkNone,
+	// NetworkHost, and NetworkDefault.
+	//
+	// If empty, the runtime decides. It may disable network access for thunks
+	// which are Pinned.
+	Network string `json:"network,omitempty"`
+
+	// Capabilities lists Linux capabilities to grant to the command in
+	// addition to the runtime's defaults; see Capabilities for valid names.
+	//
+	// Unlike Insecure, the command does not gain any other privileges. Runtimes
+	// which can't grant individual capabilities must reject the thunk rather
+	// than fall back to Insecure.
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Devices lists host devices to expose to the command, e.g. /dev/fuse.
+	//
+	// As with Capabilities, runtimes which can't expose individual devices must
+	// reject the thunk.
+	Devices []string `json:"devices,omitempty"`
+
+	// SSH may be set to true to forward the host's SSH agent to the command,
+	// with SSH_AUTH_SOCK set to its socket.
+	//
+	// Only the flag is part of the thunk; the agent's socket path and keys are
+	// not, so they do not affect caching.
+	SSH bool `json:"ssh,omitempty"`
 }
 
+const (
+	// NetworkDefault runs the command with the runtime's default network
+	// access, typically a sandboxed network with internet access.
+	NetworkDefault = "default"
+
+	// NetworkNone runs the command with no network access.
+	NetworkNone = "none"
+
+	// NetworkHost runs the command in the host's network namespace.
+	NetworkHost = "host"
+)
+
 func (thunk *Thunk) UnmarshalProto(msg proto.Message) error {
 	p, ok := msg.(*proto.Thunk)
 	if !ok {
@@ -84,6 +156,12 @@ func (thunk *Thunk) UnmarshalProto(msg proto.Message) error {
 	}
 
 	thunk.Insecure = p.Insecure
+	thunk.ImageEnv = p.ImageEnv
+	thunk.User = p.User
+	thunk.Network = p.Network
+	thunk.Capabilities = p.Capabilities
+	thunk.Devices = p.Devices
+	thunk.SSH = p.Ssh
 
 	if p.Cmd != nil {
 		if err := thunk.Cmd.UnmarshalProto(p.Cmd); err != nil {
@@ -151,6 +229,20 @@ func (thunk *Thunk) UnmarshalProto(msg proto.Message) error {
 		}
 	}
 
+	if p.ImageConfig != nil {
+		thunk.ImageConfig = &ThunkImageConfig{}
+		if err := thunk.ImageConfig.UnmarshalProto(p.ImageConfig); err != nil {
+			return fmt.Errorf("unmarshal proto image config: %w", err)
+		}
+	}
+
+	if p.Limits != nil {
+		thunk.Limits = &ThunkLimits{}
+		if err := thunk.Limits.UnmarshalProto(p.Limits); err != nil {
+			return fmt.Errorf("unmarshal proto limits: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -181,6 +273,31 @@ func (thunk Thunk) Run(ctx context.Context) error {
 	}
 }
 
+// Publish pushes the thunk to a registry as an image with the given
+// reference and returns the reference with the pushed digest.
+func (thunk Thunk) Publish(ctx context.Context, ref string) (ThunkImageRef, error) {
+	platform := thunk.Platform()
+	if platform == nil {
+		return ThunkImageRef{}, fmt.Errorf("cannot publish bass thunk: %s", thunk)
+	}
+
+	imageRef, err := ParseImageRef(*platform, ref)
+	if err != nil {
+		return ThunkImageRef{}, err
+	}
+
+	if imageRef.Digest != "" {
+		return ThunkImageRef{}, fmt.Errorf("cannot publish to a digest: %s", ref)
+	}
+
+	runtime, err := RuntimeFromContext(ctx, *platform)
+	if err != nil {
+		return ThunkImageRef{}, err
+	}
+
+	return runtime.Publish(ctx, imageRef, thunk)
+}
+
 func (thunk Thunk) Read(ctx context.Context, w io.Writer) error {
 	platform := thunk.Platform()
 
@@ -329,6 +446,80 @@ func (thunk Thunk) WithInsecure(insecure bool) Thunk {
 	return thunk
 }
 
+// WithImageEnv sets whether the thunk should run as configured by its image.
+func (thunk Thunk) WithImageEnv(imageEnv bool) Thunk {
+	thunk.ImageEnv = imageEnv
+	return thunk
+}
+
+// WithSSH forwards the host's SSH agent to the thunk.
+func (thunk Thunk) WithSSH() Thunk {
+	thunk.SSH = true
+	return thunk
+}
+
+// WithUser sets the user to run the thunk as.
+func (thunk Thunk) WithUser(user string) Thunk {
+	thunk.User = user
+	return thunk
+}
+
+// WithLimits sets the thunk's resource limits.
+func (thunk Thunk) WithLimits(limits ThunkLimits) (Thunk, error) {
+	if err := limits.Validate(); err != nil {
+		return Thunk{}, err
+	}
+
+	thunk.Limits = &limits
+	return thunk, nil
+}
+
+// WithNetwork sets the thunk's network mode.
+func (thunk Thunk) WithNetwork(mode Symbol) (Thunk, error) {
+	switch mode {
+	case NetworkDefault, NetworkNone, NetworkHost:
+		thunk.Network = string(mode)
+		return thunk, nil
+	default:
+		return Thunk{}, fmt.Errorf("unknown network mode: %s", mode)
+	}
+}
+
+// WithCapabilities grants additional Linux capabilities to the thunk.
+func (thunk Thunk) WithCapabilities(caps ...string) (Thunk, error) {
+	for _, c := range caps {
+		if err := ValidateCapability(c); err != nil {
+			return Thunk{}, err
+		}
+
+		thunk.Capabilities = appendUnique(thunk.Capabilities, c)
+	}
+
+	return thunk, nil
+}
+
+// WithDevice exposes a host device to the thunk.
+func (thunk Thunk) WithDevice(dev string) (Thunk, error) {
+	if err := ValidateDevice(dev); err != nil {
+		return Thunk{}, err
+	}
+
+	thunk.Devices = appendUnique(thunk.Devices, dev)
+	return thunk, nil
+}
+
+// appendUnique returns a copy of strs with str appended, unless it is
+// already present.
+func appendUnique(strs []string, str string) []string {
+	for _, s := range strs {
+		if s == str {
+			return strs
+		}
+	}
+
+	return append(append([]string{}, strs...), str)
+}
+
 // WithDir sets the thunk's working directory.
 func (thunk Thunk) WithDir(dir ThunkDir) Thunk {
 	thunk.Dir = &dir
@@ -355,6 +546,12 @@ func (thunk Thunk) WithLabel(key Symbol, val Value) Thunk {
 	return thunk
 }
 
+// WithImageConfig sets the OCI image config used when the thunk is exported.
+func (thunk Thunk) WithImageConfig(config ThunkImageConfig) Thunk {
+	thunk.ImageConfig = &config
+	return thunk
+}
+
 var _ Value = Thunk{}
 
 func (thunk Thunk) String() string {
@@ -485,13 +682,80 @@ func (thunk *Thunk) Platform() *Platform {
 	return thunk.Image.Platform()
 }
 
+// Pinned returns true if the thunk's image is pinned by digest and every thunk
+// that it depends on is also pinned.
+//
+// Images built from a Dockerfile are never pinned. Host paths are considered
+// pinned, since their content is sent along with the thunk.
+func (thunk Thunk) Pinned() bool {
+	if thunk.Image != nil {
+		switch {
+		case thunk.Image.Ref != nil:
+			if thunk.Image.Ref.File != nil {
+				if !thunk.Image.Ref.File.Thunk.Pinned() {
+					return false
+				}
+			} else if thunk.Image.Ref.Digest == "" {
+				return false
+			}
+		case thunk.Image.Thunk != nil:
+			if !thunk.Image.Thunk.Pinned() {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	inputs := []Value{thunk.Cmd.ToValue()}
+	inputs = append(inputs, thunk.Args...)
+	inputs = append(inputs, thunk.Stdin...)
+
+	if thunk.Env != nil {
+		inputs = append(inputs, thunk.Env)
+	}
+
+	if thunk.Dir != nil {
+		inputs = append(inputs, thunk.Dir.ToValue())
+	}
+
+	for _, mount := range thunk.Mounts {
+		inputs = append(inputs, mount.Source.ToValue())
+	}
+
+	pinned := true
+	for _, input := range inputs {
+		_, _ = Resolve(input, func(val Value) (Value, error) {
+			var path ThunkPath
+			if err := val.Decode(&path); err == nil && !path.Thunk.Pinned() {
+				pinned = false
+			}
+
+			var dep Thunk
+			if err := val.Decode(&dep); err == nil && !dep.Pinned() {
+				pinned = false
+			}
+
+			return val, nil
+		})
+	}
+
+	return pinned
+}
+
 // SHA256 returns a stable SHA256 hash derived from the thunk.
+//
+// Image configs are left out, including those of any thunks it embeds, since
+// they only apply when a thunk is exported and changing them should not re-run
+// the command.
 func (wl Thunk) SHA256() (string, error) {
 	msg, err := wl.MarshalProto()
 	if err != nil {
 		return "", err
 	}
 
+	clearImageConfigs(msg.ProtoReflect())
+
 	payload, err := gproto.Marshal(msg)
 	if err != nil {
 		return "", err
@@ -501,6 +765,39 @@ func (wl Thunk) SHA256() (string, error) {
 	return base64.URLEncoding.EncodeToString(sum[:]), nil
 }
 
+// clearImageConfigs recursively clears the image config of every thunk in the
+// message.
+func clearImageConfigs(msg protoreflect.Message) {
+	if thunk, ok := msg.Interface().(*proto.Thunk); ok {
+		thunk.ImageConfig = nil
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				clearImageConfigs(list.Get(i).Message())
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					clearImageConfigs(v.Message())
+					return true
+				})
+			}
+		default:
+			clearImageConfigs(v.Message())
+		}
+
+		return true
+	})
+}
+
 // Avatar returns an ASCII art avatar derived from the thunk.
 func (wl Thunk) Avatar() (*invaders.Invader, error) {
 	payload, err := json.Marshal(wl)
@@ -1,10 +1,13 @@
 package bass_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
 	"github.com/vito/is"
 )
 
@@ -35,3 +38,291 @@ func TestThunkSHA256(t *testing.T) {
 	// always the same value
 	is.Equal(sha2, "hNkfayFxHacmtv9iHIeF_oXqErdfEegagwrvQYwiOko=")
 }
+
+func TestThunkSecretEnv(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			File: &bass.FilePath{"run"},
+		},
+		Env: bass.Bindings{
+			"TOKEN": bass.NewSecret("token", []byte("s3cret")),
+		}.Scope(),
+	}
+
+	sha2, err := thunk.SHA256()
+	is.NoErr(err)
+
+	rotated := thunk.WithEnv(bass.Bindings{
+		"TOKEN": bass.NewSecret("token", []byte("rotated")),
+	}.Scope())
+
+	rotatedSha2, err := rotated.SHA256()
+	is.NoErr(err)
+	is.Equal(sha2, rotatedSha2)
+
+	renamed := thunk.WithEnv(bass.Bindings{
+		"TOKEN": bass.NewSecret("other-token", []byte("s3cret")),
+	}.Scope())
+
+	renamedSha2, err := renamed.SHA256()
+	is.NoErr(err)
+	is.True(sha2 != renamedSha2)
+
+	payload, err := bass.MarshalJSON(thunk)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(payload), "s3cret"))
+}
+
+func TestThunkPublish(t *testing.T) {
+	is := is.New(t)
+
+	fake := &FakeRuntime{}
+	ctx := bass.WithRuntimePool(context.Background(), &runtimes.Pool{
+		Runtimes: []runtimes.Assoc{
+			{
+				Platform: fakePlatform,
+				Runtime:  fake,
+			},
+		},
+	})
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   fakePlatform,
+				Repository: "alpine",
+			},
+		},
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"echo"},
+		},
+	}
+
+	ref, err := thunk.Publish(ctx, "registry.example.com/app:tag")
+	is.NoErr(err)
+	is.Equal(ref.Platform, fakePlatform)
+	is.Equal(ref.Repository, "registry.example.com/app")
+	is.Equal(ref.Tag, "tag")
+	is.True(ref.Digest != "")
+	is.Equal(fake.Published, []bass.Thunk{thunk})
+
+	_, err = thunk.Publish(ctx, "registry.example.com/app@sha256:"+strings.Repeat("0", 64))
+	is.True(err != nil)
+
+	_, err = bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"echo"},
+		},
+	}.Publish(ctx, "registry.example.com/app:tag")
+	is.True(err != nil)
+}
+
+func TestParseImageRef(t *testing.T) {
+	for _, example := range []struct {
+		Ref      string
+		Expected bass.ThunkImageRef
+	}{
+		{
+			Ref: "alpine",
+			Expected: bass.ThunkImageRef{
+				Platform:   fakePlatform,
+				Repository: "alpine",
+			},
+		},
+		{
+			Ref: "localhost:5000/app:tag",
+			Expected: bass.ThunkImageRef{
+				Platform:   fakePlatform,
+				Repository: "localhost:5000/app",
+				Tag:        "tag",
+			},
+		},
+		{
+			Ref: "registry.example.com/org/app@sha256:" + strings.Repeat("a", 64),
+			Expected: bass.ThunkImageRef{
+				Platform:   fakePlatform,
+				Repository: "registry.example.com/org/app",
+				Digest:     "sha256:" + strings.Repeat("a", 64),
+			},
+		},
+	} {
+		example := example
+		t.Run(example.Ref, func(t *testing.T) {
+			is := is.New(t)
+
+			ref, err := bass.ParseImageRef(fakePlatform, example.Ref)
+			is.NoErr(err)
+			is.Equal(ref, example.Expected)
+		})
+	}
+
+	_, err := bass.ParseImageRef(fakePlatform, "Not A Ref")
+	is.New(t).True(err != nil)
+}
+
+func TestThunkPinned(t *testing.T) {
+	pinnedImage := &bass.ThunkImage{
+		Ref: &bass.ThunkImageRef{
+			Platform:   fakePlatform,
+			Repository: "alpine",
+			Digest:     "sha256:" + strings.Repeat("a", 64),
+		},
+	}
+
+	taggedImage := &bass.ThunkImage{
+		Ref: &bass.ThunkImageRef{
+			Platform:   fakePlatform,
+			Repository: "alpine",
+			Tag:        "latest",
+		},
+	}
+
+	pinned := bass.Thunk{
+		Image: pinnedImage,
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"echo"},
+		},
+	}
+
+	unpinned := bass.Thunk{
+		Image: taggedImage,
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{"echo"},
+		},
+	}
+
+	pinnedPath := bass.ThunkPath{
+		Thunk: pinned,
+		Path:  bass.ParseFileOrDirPath("out"),
+	}
+
+	unpinnedPath := bass.ThunkPath{
+		Thunk: unpinned,
+		Path:  bass.ParseFileOrDirPath("out"),
+	}
+
+	for _, example := range []struct {
+		Name   string
+		Thunk  bass.Thunk
+		Pinned bool
+	}{
+		{
+			Name:   "digest",
+			Thunk:  pinned,
+			Pinned: true,
+		},
+		{
+			Name:   "tag",
+			Thunk:  unpinned,
+			Pinned: false,
+		},
+		{
+			Name: "pinned image thunk",
+			Thunk: bass.Thunk{
+				Image: &bass.ThunkImage{Thunk: &pinned},
+				Cmd:   bass.ThunkCmd{Cmd: &bass.CommandPath{"echo"}},
+			},
+			Pinned: true,
+		},
+		{
+			Name: "unpinned image thunk",
+			Thunk: bass.Thunk{
+				Image: &bass.ThunkImage{Thunk: &unpinned},
+				Cmd:   bass.ThunkCmd{Cmd: &bass.CommandPath{"echo"}},
+			},
+			Pinned: false,
+		},
+		{
+			Name:   "pinned arg",
+			Thunk:  pinned.AppendArgs(pinnedPath),
+			Pinned: true,
+		},
+		{
+			Name:   "unpinned arg",
+			Thunk:  pinned.AppendArgs(bass.NewList(bass.String("nested"), unpinnedPath)),
+			Pinned: false,
+		},
+		{
+			Name:   "unpinned env",
+			Thunk:  pinned.WithEnv(bass.Bindings{"FOO": unpinnedPath}.Scope()),
+			Pinned: false,
+		},
+		{
+			Name: "unpinned mount",
+			Thunk: pinned.WithMount(bass.ThunkMountSource{
+				ThunkPath: &unpinnedPath,
+			}, bass.ParseFileOrDirPath("in")),
+			Pinned: false,
+		},
+		{
+			Name: "dockerfile",
+			Thunk: bass.Thunk{
+				Image: &bass.ThunkImage{
+					DockerBuild: &bass.ThunkDockerBuild{
+						Platform: fakePlatform,
+						Context: bass.ImageBuildInput{
+							Thunk: &pinnedPath,
+						},
+					},
+				},
+				Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{"echo"}},
+			},
+			Pinned: false,
+		},
+	} {
+		example := example
+		t.Run(example.Name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(example.Thunk.Pinned(), example.Pinned)
+		})
+	}
+}
+
+func TestThunkSHA256ImageConfig(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Cmd: bass.ThunkCmd{
+			File: &bass.FilePath{"run"},
+		},
+	}
+
+	sha2, err := thunk.SHA256()
+	is.NoErr(err)
+
+	configured := thunk.WithImageConfig(bass.ThunkImageConfig{
+		Entrypoint: []string{"/bin/sh"},
+	})
+
+	configuredSha2, err := configured.SHA256()
+	is.NoErr(err)
+	is.Equal(sha2, configuredSha2)
+
+	// image configs of embedded thunks are ignored too
+	child := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &thunk},
+		Cmd: bass.ThunkCmd{
+			Thunk: &bass.ThunkPath{
+				Thunk: thunk,
+				Path:  bass.ParseFileOrDirPath("run"),
+			},
+		},
+	}
+
+	childSha2, err := child.SHA256()
+	is.NoErr(err)
+
+	child.Image = &bass.ThunkImage{Thunk: &configured}
+	child.Cmd.Thunk.Thunk = configured
+
+	configuredChildSha2, err := child.SHA256()
+	is.NoErr(err)
+	is.Equal(childSha2, configuredChildSha2)
+
+	// the image config is still encoded
+	payload, err := bass.MarshalJSON(configured)
+	is.NoErr(err)
+	is.True(strings.Contains(string(payload), "/bin/sh"))
+}
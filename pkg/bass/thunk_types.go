@@ -2,7 +2,12 @@ package bass
 
 import (
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
 
+	"github.com/docker/distribution/reference"
+	"github.com/docker/go-units"
 	"github.com/hashicorp/go-multierror"
 	"github.com/vito/bass/pkg/proto"
 )
@@ -11,6 +16,32 @@ import (
 type ThunkMount struct {
 	Source ThunkMountSource `json:"source"`
 	Target FileOrDirPath    `json:"target"`
+
+	// Owner is the numeric uid[:gid] to own the mounted content. The gid
+	// defaults to the uid.
+	Owner string `json:"owner,omitempty"`
+}
+
+// ParseOwner parses a mount owner in the form uid[:gid] into its uid and gid.
+// The gid defaults to the uid.
+func ParseOwner(owner string) (int, int, error) {
+	uidStr, gidStr, hasGID := strings.Cut(owner, ":")
+
+	uid, err := strconv.Atoi(uidStr)
+	if err != nil || uid < 0 {
+		return 0, 0, fmt.Errorf("invalid owner uid: %q", owner)
+	}
+
+	if !hasGID {
+		return uid, uid, nil
+	}
+
+	gid, err := strconv.Atoi(gidStr)
+	if err != nil || gid < 0 {
+		return 0, 0, fmt.Errorf("invalid owner gid: %q", owner)
+	}
+
+	return uid, gid, nil
 }
 
 func (mount *ThunkMount) UnmarshalProto(msg proto.Message) error {
@@ -27,11 +58,15 @@ func (mount *ThunkMount) UnmarshalProto(msg proto.Message) error {
 		return fmt.Errorf("unmarshal proto target: %w", err)
 	}
 
+	mount.Owner = p.GetOwner()
+
 	return nil
 }
 
 func (mount ThunkMount) MarshalProto() (proto.Message, error) {
-	tm := &proto.ThunkMount{}
+	tm := &proto.ThunkMount{
+		Owner: mount.Owner,
+	}
 
 	src, err := mount.Source.MarshalProto()
 	if err != nil {
@@ -83,6 +118,30 @@ func (ref ThunkImageRef) Ref() (string, error) {
 	}
 }
 
+// ParseImageRef parses a reference to an image on a registry, e.g.
+// "registry.example.com/app:tag".
+func ParseImageRef(platform Platform, str string) (ThunkImageRef, error) {
+	named, err := reference.ParseNormalizedNamed(str)
+	if err != nil {
+		return ThunkImageRef{}, fmt.Errorf("parse image ref: %w", err)
+	}
+
+	ref := ThunkImageRef{
+		Platform:   platform,
+		Repository: reference.FamiliarName(named),
+	}
+
+	if tagged, ok := named.(reference.Tagged); ok {
+		ref.Tag = tagged.Tag()
+	}
+
+	if digested, ok := named.(reference.Digested); ok {
+		ref.Digest = digested.Digest().String()
+	}
+
+	return ref, nil
+}
+
 func (ref *ThunkImageRef) UnmarshalProto(msg proto.Message) error {
 	p, ok := msg.(*proto.ThunkImageRef)
 	if !ok {
@@ -141,6 +200,392 @@ func (ref ThunkImageRef) MarshalProto() (proto.Message, error) {
 	return pv, nil
 }
 
+// ThunkDockerBuild specifies an OCI image built from a Dockerfile.
+type ThunkDockerBuild struct {
+	// The platform to target; influences runtime selection.
+	Platform Platform `json:"platform"`
+
+	// The directory to use as the build context.
+	Context ImageBuildInput `json:"context"`
+
+	// The path to the Dockerfile, relative to the build context. Defaults to
+	// ./Dockerfile.
+	Dockerfile FilePath `json:"dockerfile,omitempty"`
+
+	// The stage to build in a multi-stage Dockerfile. Defaults to the last
+	// stage.
+	Target string `json:"target,omitempty"`
+
+	// Values for ARG instructions in the Dockerfile.
+	Args *Scope `json:"args,omitempty"`
+}
+
+// DockerfilePath returns the path to the Dockerfile, relative to the build
+// context.
+func (build ThunkDockerBuild) DockerfilePath() string {
+	if build.Dockerfile.Path == "" {
+		return "Dockerfile"
+	}
+
+	return path.Clean(build.Dockerfile.Slash())
+}
+
+// BuildArgs returns the string value of each build arg.
+func (build ThunkDockerBuild) BuildArgs() (map[string]string, error) {
+	args := map[string]string{}
+	err := eachString(build.Args, func(name, val string) {
+		args[name] = val
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build arg %w", err)
+	}
+
+	return args, nil
+}
+
+func (build *ThunkDockerBuild) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ThunkDockerBuild)
+	if !ok {
+		return DecodeError{msg, build}
+	}
+
+	if err := build.Platform.UnmarshalProto(p.Platform); err != nil {
+		return fmt.Errorf("platform: %w", err)
+	}
+
+	if err := build.Context.UnmarshalProto(p.GetContext()); err != nil {
+		return fmt.Errorf("context: %w", err)
+	}
+
+	if p.GetDockerfile() != nil {
+		if err := build.Dockerfile.UnmarshalProto(p.GetDockerfile()); err != nil {
+			return fmt.Errorf("dockerfile: %w", err)
+		}
+	}
+
+	build.Target = p.GetTarget()
+
+	if len(p.Args) > 0 {
+		build.Args = NewEmptyScope()
+
+		for _, bnd := range p.Args {
+			val, err := FromProto(bnd.Value)
+			if err != nil {
+				return fmt.Errorf("arg %s: %w", bnd.Symbol, err)
+			}
+
+			build.Args.Set(Symbol(bnd.Symbol), val)
+		}
+	}
+
+	return nil
+}
+
+func (build ThunkDockerBuild) MarshalProto() (proto.Message, error) {
+	pv := &proto.ThunkDockerBuild{
+		Platform: &proto.Platform{
+			Os:   build.Platform.OS,
+			Arch: build.Platform.Arch,
+		},
+	}
+
+	cv, err := build.Context.MarshalProto()
+	if err != nil {
+		return nil, fmt.Errorf("context: %w", err)
+	}
+
+	pv.Context = cv.(*proto.ImageBuildInput)
+
+	if build.Dockerfile.Path != "" {
+		fv, err := build.Dockerfile.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("dockerfile: %w", err)
+		}
+
+		pv.Dockerfile = fv.(*proto.FilePath)
+	}
+
+	if build.Target != "" {
+		pv.Target = &build.Target
+	}
+
+	if build.Args != nil {
+		err := build.Args.Each(func(sym Symbol, val Value) error {
+			av, err := MarshalProto(val)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sym, err)
+			}
+
+			pv.Args = append(pv.Args, &proto.Binding{
+				Symbol: string(sym),
+				Value:  av,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("args: %w", err)
+		}
+	}
+
+	return pv, nil
+}
+
+// ThunkLimits configures resource limits for running a thunk.
+type ThunkLimits struct {
+	// The maximum amount of memory, e.g. "4GB".
+	Memory string `json:"memory,omitempty"`
+
+	// The number of CPUs the command may use.
+	CPUs int `json:"cpus,omitempty"`
+
+	// The maximum number of processes and threads.
+	Pids int `json:"pids,omitempty"`
+
+	// The size of the /tmp and /dev/shm tmpfs mounts, e.g. "1GB".
+	TmpfsSize string `json:"tmpfs-size,omitempty"`
+}
+
+// MemoryBytes returns the memory limit in bytes, or 0 if unset.
+func (limits ThunkLimits) MemoryBytes() (int64, error) {
+	return parseLimitSize("memory", limits.Memory)
+}
+
+// TmpfsSizeBytes returns the tmpfs size limit in bytes, or 0 if unset.
+func (limits ThunkLimits) TmpfsSizeBytes() (int64, error) {
+	return parseLimitSize("tmpfs-size", limits.TmpfsSize)
+}
+
+// Validate returns an error if any of the limits are invalid.
+func (limits ThunkLimits) Validate() error {
+	if _, err := limits.MemoryBytes(); err != nil {
+		return err
+	}
+
+	if _, err := limits.TmpfsSizeBytes(); err != nil {
+		return err
+	}
+
+	if limits.CPUs < 0 {
+		return fmt.Errorf("invalid cpus limit: %d", limits.CPUs)
+	}
+
+	if limits.Pids < 0 {
+		return fmt.Errorf("invalid pids limit: %d", limits.Pids)
+	}
+
+	return nil
+}
+
+func parseLimitSize(name, size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	bytes, err := units.RAMInBytes(size)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s limit: %w", name, err)
+	}
+
+	if bytes <= 0 {
+		return 0, fmt.Errorf("invalid %s limit: %q", name, size)
+	}
+
+	return bytes, nil
+}
+
+func (limits *ThunkLimits) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ThunkLimits)
+	if !ok {
+		return DecodeError{msg, limits}
+	}
+
+	limits.Memory = p.Memory
+	limits.CPUs = int(p.Cpus)
+	limits.Pids = int(p.Pids)
+	limits.TmpfsSize = p.TmpfsSize
+
+	return nil
+}
+
+func (limits ThunkLimits) MarshalProto() (proto.Message, error) {
+	return &proto.ThunkLimits{
+		Memory:    limits.Memory,
+		Cpus:      int64(limits.CPUs),
+		Pids:      int64(limits.Pids),
+		TmpfsSize: limits.TmpfsSize,
+	}, nil
+}
+
+// ThunkImageConfig configures the OCI image config of an exported thunk.
+type ThunkImageConfig struct {
+	// The command to run when a container is started from the image.
+	Entrypoint []string `json:"entrypoint,omitempty"`
+
+	// Default arguments to the entrypoint.
+	Cmd []string `json:"cmd,omitempty"`
+
+	// Environment variables to set, in addition to those of the thunk's base
+	// image.
+	Env *Scope `json:"env,omitempty"`
+
+	// The user, and optionally group, to run as.
+	User string `json:"user,omitempty"`
+
+	// Labels to set on the image.
+	Labels *Scope `json:"labels,omitempty"`
+
+	// Ports to expose, e.g. "80/tcp". A port with no protocol defaults to TCP.
+	ExposedPorts []string `json:"exposed-ports,omitempty"`
+}
+
+// EnvStrings returns each env var in the form NAME=value.
+func (config ThunkImageConfig) EnvStrings() ([]string, error) {
+	var env []string
+	err := eachString(config.Env, func(name, val string) {
+		env = append(env, name+"="+val)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("env: %w", err)
+	}
+
+	return env, nil
+}
+
+// LabelMap returns the labels as strings.
+func (config ThunkImageConfig) LabelMap() (map[string]string, error) {
+	labels := map[string]string{}
+	err := eachString(config.Labels, func(name, val string) {
+		labels[name] = val
+	})
+	if err != nil {
+		return nil, fmt.Errorf("labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// Ports returns each exposed port with its protocol.
+func (config ThunkImageConfig) Ports() []string {
+	var ports []string
+	for _, port := range config.ExposedPorts {
+		if !strings.Contains(port, "/") {
+			port += "/tcp"
+		}
+
+		ports = append(ports, port)
+	}
+
+	return ports
+}
+
+// eachString calls cb with each binding in the scope, which must all be
+// strings.
+func eachString(scope *Scope, cb func(string, string)) error {
+	if scope == nil {
+		return nil
+	}
+
+	return scope.Each(func(name Symbol, val Value) error {
+		var str string
+		if err := val.Decode(&str); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		cb(name.String(), str)
+		return nil
+	})
+}
+
+func (config *ThunkImageConfig) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ThunkImageConfig)
+	if !ok {
+		return DecodeError{msg, config}
+	}
+
+	config.Entrypoint = p.Entrypoint
+	config.Cmd = p.Cmd
+	config.User = p.GetUser()
+	config.ExposedPorts = p.ExposedPorts
+
+	if len(p.Env) > 0 {
+		config.Env = NewEmptyScope()
+
+		for _, bnd := range p.Env {
+			val, err := FromProto(bnd.Value)
+			if err != nil {
+				return fmt.Errorf("env[%s]: %w", bnd.Symbol, err)
+			}
+
+			config.Env.Set(Symbol(bnd.Symbol), val)
+		}
+	}
+
+	if len(p.Labels) > 0 {
+		config.Labels = NewEmptyScope()
+
+		for _, bnd := range p.Labels {
+			val, err := FromProto(bnd.Value)
+			if err != nil {
+				return fmt.Errorf("label[%s]: %w", bnd.Symbol, err)
+			}
+
+			config.Labels.Set(Symbol(bnd.Symbol), val)
+		}
+	}
+
+	return nil
+}
+
+func (config ThunkImageConfig) MarshalProto() (proto.Message, error) {
+	pv := &proto.ThunkImageConfig{
+		Entrypoint:   config.Entrypoint,
+		Cmd:          config.Cmd,
+		ExposedPorts: config.ExposedPorts,
+	}
+
+	if config.User != "" {
+		pv.User = &config.User
+	}
+
+	if config.Env != nil {
+		err := config.Env.Each(func(sym Symbol, val Value) error {
+			ev, err := MarshalProto(val)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sym, err)
+			}
+
+			pv.Env = append(pv.Env, &proto.Binding{
+				Symbol: string(sym),
+				Value:  ev,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("env: %w", err)
+		}
+	}
+
+	if config.Labels != nil {
+		err := config.Labels.Each(func(sym Symbol, val Value) error {
+			lv, err := MarshalProto(val)
+			if err != nil {
+				return fmt.Errorf("%s: %w", sym, err)
+			}
+
+			pv.Labels = append(pv.Labels, &proto.Binding{
+				Symbol: string(sym),
+				Value:  lv,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("labels: %w", err)
+		}
+	}
+
+	return pv, nil
+}
+
 // Platform configures an OCI image platform.
 type Platform struct {
 	OS   string `json:"os"`
@@ -169,6 +614,19 @@ func (platform Platform) String() string {
 	return str
 }
 
+// ParsePlatform parses a platform in the form os[/arch], e.g. linux/amd64.
+func ParsePlatform(str string) (Platform, error) {
+	osName, arch, _ := strings.Cut(str, "/")
+	if osName == "" || strings.Contains(arch, "/") {
+		return Platform{}, fmt.Errorf("invalid platform %q: must be os or os/arch", str)
+	}
+
+	return Platform{
+		OS:   osName,
+		Arch: arch,
+	}, nil
+}
+
 // LinuxPlatform is the minimum configuration to select a Linux runtime.
 var LinuxPlatform = Platform{
 	OS: "linux",
@@ -342,11 +800,12 @@ func (enum *ThunkMountSource) FromValue(val Value) error {
 }
 
 // ThunkImage specifies the base image of a thunk - either a reference to be
-// fetched, a thunk path (e.g. of a OCI/Docker tarball), or a lower thunk to
-// run.
+// fetched, a thunk path (e.g. of a OCI/Docker tarball), a lower thunk to run,
+// or a Dockerfile to build.
 type ThunkImage struct {
-	Ref   *ThunkImageRef
-	Thunk *Thunk
+	Ref         *ThunkImageRef
+	Thunk       *Thunk
+	DockerBuild *ThunkDockerBuild
 }
 
 func (img *ThunkImage) UnmarshalProto(msg proto.Message) error {
@@ -378,6 +837,11 @@ func (img *ThunkImage) UnmarshalProto(msg proto.Message) error {
 		if err := img.Thunk.UnmarshalProto(protoImage.GetThunk()); err != nil {
 			return err
 		}
+	} else if protoImage.GetDockerBuild() != nil {
+		img.DockerBuild = &ThunkDockerBuild{}
+		if err := img.DockerBuild.UnmarshalProto(protoImage.GetDockerBuild()); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -404,6 +868,15 @@ func (img ThunkImage) MarshalProto() (proto.Message, error) {
 		ti.Image = &proto.ThunkImage_Thunk{
 			Thunk: tv.(*proto.Thunk),
 		}
+	} else if img.DockerBuild != nil {
+		bv, err := img.DockerBuild.MarshalProto()
+		if err != nil {
+			return nil, fmt.Errorf("docker build: %w", err)
+		}
+
+		ti.Image = &proto.ThunkImage_DockerBuild{
+			DockerBuild: bv.(*proto.ThunkDockerBuild),
+		}
 	} else {
 		return nil, fmt.Errorf("unexpected image type: %T", img.ToValue())
 	}
@@ -414,6 +887,8 @@ func (img ThunkImage) MarshalProto() (proto.Message, error) {
 func (img ThunkImage) Platform() *Platform {
 	if img.Ref != nil {
 		return &img.Ref.Platform
+	} else if img.DockerBuild != nil {
+		return &img.DockerBuild.Platform
 	} else {
 		return img.Thunk.Platform()
 	}
@@ -429,6 +904,9 @@ func (image ThunkImage) ToValue() Value {
 	} else if image.Thunk != nil {
 		val, _ := ValueOf(*image.Thunk)
 		return val
+	} else if image.DockerBuild != nil {
+		val, _ := ValueOf(*image.DockerBuild)
+		return val
 	} else {
 		panic("empty ThunkImage or unhandled type?")
 	}
@@ -445,6 +923,21 @@ func (image ThunkImage) MarshalJSON() ([]byte, error) {
 func (image *ThunkImage) FromValue(val Value) error {
 	var errs error
 
+	// docker builds are identified by their context, since they would
+	// otherwise decode as a ref with only a platform
+	var obj *Scope
+	if err := val.Decode(&obj); err == nil {
+		if _, found := obj.Get("context"); found {
+			var build ThunkDockerBuild
+			if err := val.Decode(&build); err != nil {
+				return fmt.Errorf("image enum: %T: %w", build, err)
+			}
+
+			image.DockerBuild = &build
+			return nil
+		}
+	}
+
 	var ref ThunkImageRef
 	if err := val.Decode(&ref); err == nil {
 		image.Ref = &ref
@@ -647,6 +1140,137 @@ func (path *ThunkCmd) FromValue(val Value) error {
 	return errs
 }
 
+// ImageBuildInput is a directory used as the context for building an image.
+type ImageBuildInput struct {
+	Thunk *ThunkPath
+	Host  *HostPath
+	FS    *FSPath
+}
+
+func (input *ImageBuildInput) UnmarshalProto(msg proto.Message) error {
+	p, ok := msg.(*proto.ImageBuildInput)
+	if !ok {
+		return fmt.Errorf("unmarshal proto: %w", DecodeError{msg, input})
+	}
+
+	switch x := p.GetInput().(type) {
+	case *proto.ImageBuildInput_Thunk:
+		input.Thunk = &ThunkPath{}
+		return input.Thunk.UnmarshalProto(x.Thunk)
+	case *proto.ImageBuildInput_Host:
+		input.Host = &HostPath{}
+		return input.Host.UnmarshalProto(x.Host)
+	case *proto.ImageBuildInput_Logical:
+		input.FS = &FSPath{}
+		return input.FS.UnmarshalProto(x.Logical)
+	default:
+		return fmt.Errorf("unmarshal proto: unknown type: %T", x)
+	}
+}
+
+func (input ImageBuildInput) MarshalProto() (proto.Message, error) {
+	pv := &proto.ImageBuildInput{}
+
+	if input.Thunk != nil {
+		tv, err := input.Thunk.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+
+		pv.Input = &proto.ImageBuildInput_Thunk{
+			Thunk: tv.(*proto.ThunkPath),
+		}
+	} else if input.Host != nil {
+		hv, err := input.Host.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+
+		pv.Input = &proto.ImageBuildInput_Host{
+			Host: hv.(*proto.HostPath),
+		}
+	} else if input.FS != nil {
+		fv, err := input.FS.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+
+		pv.Input = &proto.ImageBuildInput_Logical{
+			Logical: fv.(*proto.LogicalPath),
+		}
+	} else {
+		return nil, fmt.Errorf("unexpected image build input type: %T", input.ToValue())
+	}
+
+	return pv, nil
+}
+
+var _ Decodable = &ImageBuildInput{}
+var _ Encodable = ImageBuildInput{}
+
+func (input ImageBuildInput) ToValue() Value {
+	if input.Thunk != nil {
+		val, _ := ValueOf(*input.Thunk)
+		return val
+	} else if input.Host != nil {
+		val, _ := ValueOf(*input.Host)
+		return val
+	} else {
+		val, _ := ValueOf(*input.FS)
+		return val
+	}
+}
+
+func (input *ImageBuildInput) UnmarshalJSON(payload []byte) error {
+	return UnmarshalJSON(payload, input)
+}
+
+func (input ImageBuildInput) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(input.ToValue())
+}
+
+func (input *ImageBuildInput) FromValue(val Value) error {
+	var errs error
+
+	var tp ThunkPath
+	if err := val.Decode(&tp); err == nil {
+		if tp.Path.Dir == nil {
+			return fmt.Errorf("build context must be a directory: %s", tp)
+		}
+
+		input.Thunk = &tp
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", tp, err))
+	}
+
+	var hp HostPath
+	if err := val.Decode(&hp); err == nil {
+		if hp.Path.Dir == nil {
+			return fmt.Errorf("build context must be a directory: %s", hp)
+		}
+
+		input.Host = &hp
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", hp, err))
+	}
+
+	var fsp *FSPath
+	if err := val.Decode(&fsp); err == nil {
+		if !fsp.Path.FilesystemPath().IsDir() {
+			return fmt.Errorf("build context must be a directory: %s", fsp)
+		}
+
+		input.FS = fsp
+		return nil
+	} else {
+		errs = multierror.Append(errs, fmt.Errorf("%T: %w", fsp, err))
+	}
+
+	return errs
+}
+
 type ThunkDir struct {
 	Dir      *DirPath
 	ThunkDir *ThunkPath
@@ -9,6 +9,9 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -67,6 +70,206 @@ func (x *Memosphere) GetMemos() []*Memosphere_Memo {
 	return nil
 }
 
+type MemoKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Module  *Thunk `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Binding string `protobuf:"bytes,2,opt,name=binding,proto3" json:"binding,omitempty"`
+	Input   *Value `protobuf:"bytes,3,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (x *MemoKey) Reset() {
+	*x = MemoKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_memo_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoKey) ProtoMessage() {}
+
+func (x *MemoKey) ProtoReflect() protoreflect.Message {
+	mi := &file_memo_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoKey.ProtoReflect.Descriptor instead.
+func (*MemoKey) Descriptor() ([]byte, []int) {
+	return file_memo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MemoKey) GetModule() *Thunk {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *MemoKey) GetBinding() string {
+	if x != nil {
+		return x.Binding
+	}
+	return ""
+}
+
+func (x *MemoKey) GetInput() *Value {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type StoreMemoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    *MemoKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Output *Value   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// unset means forever
+	Ttl *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *StoreMemoRequest) Reset() {
+	*x = StoreMemoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_memo_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoreMemoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreMemoRequest) ProtoMessage() {}
+
+func (x *StoreMemoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_memo_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreMemoRequest.ProtoReflect.Descriptor instead.
+func (*StoreMemoRequest) Descriptor() ([]byte, []int) {
+	return file_memo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StoreMemoRequest) GetKey() *MemoKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StoreMemoRequest) GetOutput() *Value {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *StoreMemoRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type RetrieveMemoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Found  bool   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Output *Value `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// set if the memos know when the result was recorded
+	RecordedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	// unset means forever
+	Ttl *durationpb.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *RetrieveMemoResponse) Reset() {
+	*x = RetrieveMemoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_memo_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetrieveMemoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetrieveMemoResponse) ProtoMessage() {}
+
+func (x *RetrieveMemoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_memo_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetrieveMemoResponse.ProtoReflect.Descriptor instead.
+func (*RetrieveMemoResponse) Descriptor() ([]byte, []int) {
+	return file_memo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RetrieveMemoResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *RetrieveMemoResponse) GetOutput() *Value {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *RetrieveMemoResponse) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+func (x *RetrieveMemoResponse) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
 type Memosphere_Memo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -79,7 +282,7 @@ type Memosphere_Memo struct {
 func (x *Memosphere_Memo) Reset() {
 	*x = Memosphere_Memo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_memo_proto_msgTypes[1]
+		mi := &file_memo_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -92,7 +295,7 @@ func (x *Memosphere_Memo) String() string {
 func (*Memosphere_Memo) ProtoMessage() {}
 
 func (x *Memosphere_Memo) ProtoReflect() protoreflect.Message {
-	mi := &file_memo_proto_msgTypes[1]
+	mi := &file_memo_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -134,7 +337,7 @@ type Memosphere_Call struct {
 func (x *Memosphere_Call) Reset() {
 	*x = Memosphere_Call{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_memo_proto_msgTypes[2]
+		mi := &file_memo_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -147,7 +350,7 @@ func (x *Memosphere_Call) String() string {
 func (*Memosphere_Call) ProtoMessage() {}
 
 func (x *Memosphere_Call) ProtoReflect() protoreflect.Message {
-	mi := &file_memo_proto_msgTypes[2]
+	mi := &file_memo_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,12 +387,17 @@ type Memosphere_Result struct {
 
 	Input  *Value `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
 	Output *Value `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	// when the output was recorded
+	RecordedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	// how long the output remains valid after it was recorded; unset means
+	// forever
+	Ttl *durationpb.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
 }
 
 func (x *Memosphere_Result) Reset() {
 	*x = Memosphere_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_memo_proto_msgTypes[3]
+		mi := &file_memo_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -202,7 +410,7 @@ func (x *Memosphere_Result) String() string {
 func (*Memosphere_Result) ProtoMessage() {}
 
 func (x *Memosphere_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_memo_proto_msgTypes[3]
+	mi := &file_memo_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,32 +440,97 @@ func (x *Memosphere_Result) GetOutput() *Value {
 	return nil
 }
 
+func (x *Memosphere_Result) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+func (x *Memosphere_Result) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
 var File_memo_proto protoreflect.FileDescriptor
 
 var file_memo_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6d, 0x65, 0x6d, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x62, 0x61,
-	0x73, 0x73, 0x1a, 0x0a, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xba,
-	0x02, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x12, 0x2b, 0x0a,
-	0x05, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62,
-	0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x4d,
-	0x65, 0x6d, 0x6f, 0x52, 0x05, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x1a, 0x58, 0x0a, 0x04, 0x4d, 0x65,
-	0x6d, 0x6f, 0x12, 0x23, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x52,
-	0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65,
-	0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x05, 0x63,
-	0x61, 0x6c, 0x6c, 0x73, 0x1a, 0x53, 0x0a, 0x04, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07,
-	0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62,
-	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d,
-	0x65, 0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x50, 0x0a, 0x06, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x0b, 0x5a, 0x09, 0x70,
-	0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x73, 0x1a, 0x0a, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x03, 0x0a,
+	0x0a, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x6d,
+	0x65, 0x6d, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73,
+	0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x4d, 0x65, 0x6d,
+	0x6f, 0x52, 0x05, 0x6d, 0x65, 0x6d, 0x6f, 0x73, 0x1a, 0x58, 0x0a, 0x04, 0x4d, 0x65, 0x6d, 0x6f,
+	0x12, 0x23, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x06, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x6f,
+	0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x05, 0x63, 0x61, 0x6c,
+	0x6c, 0x73, 0x1a, 0x53, 0x0a, 0x04, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x69, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65, 0x6d,
+	0x6f, 0x73, 0x70, 0x68, 0x65, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0xba, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x03, 0x74, 0x74, 0x6c, 0x22, 0x6b, 0x0a, 0x07, 0x4d, 0x65, 0x6d, 0x6f, 0x4b, 0x65, 0x79, 0x12,
+	0x23, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x54, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x06, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x21,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x62, 0x61, 0x73, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x22, 0x85, 0x01, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x4b,
+	0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x03,
+	0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x23, 0x0a, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x3b, 0x0a,
+	0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x32, 0xae, 0x01, 0x0a, 0x05, 0x4d, 0x65, 0x6d, 0x6f,
+	0x73, 0x12, 0x39, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x62, 0x61, 0x73,
+	0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x12, 0x0d, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e,
+	0x4d, 0x65, 0x6d, 0x6f, 0x4b, 0x65, 0x79, 0x1a, 0x1a, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x52,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12,
+	0x0d, 0x2e, 0x62, 0x61, 0x73, 0x73, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x4b, 0x65, 0x79, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -272,27 +545,49 @@ func file_memo_proto_rawDescGZIP() []byte {
 	return file_memo_proto_rawDescData
 }
 
-var file_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_memo_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_memo_proto_goTypes = []interface{}{
-	(*Memosphere)(nil),        // 0: bass.Memosphere
-	(*Memosphere_Memo)(nil),   // 1: bass.Memosphere.Memo
-	(*Memosphere_Call)(nil),   // 2: bass.Memosphere.Call
-	(*Memosphere_Result)(nil), // 3: bass.Memosphere.Result
-	(*Thunk)(nil),             // 4: bass.Thunk
-	(*Value)(nil),             // 5: bass.Value
+	(*Memosphere)(nil),            // 0: bass.Memosphere
+	(*MemoKey)(nil),               // 1: bass.MemoKey
+	(*StoreMemoRequest)(nil),      // 2: bass.StoreMemoRequest
+	(*RetrieveMemoResponse)(nil),  // 3: bass.RetrieveMemoResponse
+	(*Memosphere_Memo)(nil),       // 4: bass.Memosphere.Memo
+	(*Memosphere_Call)(nil),       // 5: bass.Memosphere.Call
+	(*Memosphere_Result)(nil),     // 6: bass.Memosphere.Result
+	(*Thunk)(nil),                 // 7: bass.Thunk
+	(*Value)(nil),                 // 8: bass.Value
+	(*durationpb.Duration)(nil),   // 9: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 11: google.protobuf.Empty
 }
 var file_memo_proto_depIdxs = []int32{
-	1, // 0: bass.Memosphere.memos:type_name -> bass.Memosphere.Memo
-	4, // 1: bass.Memosphere.Memo.module:type_name -> bass.Thunk
-	2, // 2: bass.Memosphere.Memo.calls:type_name -> bass.Memosphere.Call
-	3, // 3: bass.Memosphere.Call.results:type_name -> bass.Memosphere.Result
-	5, // 4: bass.Memosphere.Result.input:type_name -> bass.Value
-	5, // 5: bass.Memosphere.Result.output:type_name -> bass.Value
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4,  // 0: bass.Memosphere.memos:type_name -> bass.Memosphere.Memo
+	7,  // 1: bass.MemoKey.module:type_name -> bass.Thunk
+	8,  // 2: bass.MemoKey.input:type_name -> bass.Value
+	1,  // 3: bass.StoreMemoRequest.key:type_name -> bass.MemoKey
+	8,  // 4: bass.StoreMemoRequest.output:type_name -> bass.Value
+	9,  // 5: bass.StoreMemoRequest.ttl:type_name -> google.protobuf.Duration
+	8,  // 6: bass.RetrieveMemoResponse.output:type_name -> bass.Value
+	10, // 7: bass.RetrieveMemoResponse.recorded_at:type_name -> google.protobuf.Timestamp
+	9,  // 8: bass.RetrieveMemoResponse.ttl:type_name -> google.protobuf.Duration
+	7,  // 9: bass.Memosphere.Memo.module:type_name -> bass.Thunk
+	5,  // 10: bass.Memosphere.Memo.calls:type_name -> bass.Memosphere.Call
+	6,  // 11: bass.Memosphere.Call.results:type_name -> bass.Memosphere.Result
+	8,  // 12: bass.Memosphere.Result.input:type_name -> bass.Value
+	8,  // 13: bass.Memosphere.Result.output:type_name -> bass.Value
+	10, // 14: bass.Memosphere.Result.recorded_at:type_name -> google.protobuf.Timestamp
+	9,  // 15: bass.Memosphere.Result.ttl:type_name -> google.protobuf.Duration
+	2,  // 16: bass.Memos.Store:input_type -> bass.StoreMemoRequest
+	1,  // 17: bass.Memos.Retrieve:input_type -> bass.MemoKey
+	1,  // 18: bass.Memos.Remove:input_type -> bass.MemoKey
+	11, // 19: bass.Memos.Store:output_type -> google.protobuf.Empty
+	3,  // 20: bass.Memos.Retrieve:output_type -> bass.RetrieveMemoResponse
+	11, // 21: bass.Memos.Remove:output_type -> google.protobuf.Empty
+	19, // [19:22] is the sub-list for method output_type
+	16, // [16:19] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_memo_proto_init() }
@@ -315,7 +610,7 @@ func file_memo_proto_init() {
 			}
 		}
 		file_memo_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Memosphere_Memo); i {
+			switch v := v.(*MemoKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -327,7 +622,7 @@ func file_memo_proto_init() {
 			}
 		}
 		file_memo_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Memosphere_Call); i {
+			switch v := v.(*StoreMemoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -339,6 +634,42 @@ func file_memo_proto_init() {
 			}
 		}
 		file_memo_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetrieveMemoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_memo_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Memosphere_Memo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_memo_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Memosphere_Call); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_memo_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Memosphere_Result); i {
 			case 0:
 				return &v.state
@@ -357,9 +688,9 @@ func file_memo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_memo_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
-			NumServices:   0,
+			NumServices:   1,
 		},
 		GoTypes:           file_memo_proto_goTypes,
 		DependencyIndexes: file_memo_proto_depIdxs,
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: memo.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// MemosClient is the client API for Memos service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MemosClient interface {
+	Store(ctx context.Context, in *StoreMemoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Retrieve(ctx context.Context, in *MemoKey, opts ...grpc.CallOption) (*RetrieveMemoResponse, error)
+	Remove(ctx context.Context, in *MemoKey, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type memosClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMemosClient(cc grpc.ClientConnInterface) MemosClient {
+	return &memosClient{cc}
+}
+
+func (c *memosClient) Store(ctx context.Context, in *StoreMemoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/bass.Memos/Store", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memosClient) Retrieve(ctx context.Context, in *MemoKey, opts ...grpc.CallOption) (*RetrieveMemoResponse, error) {
+	out := new(RetrieveMemoResponse)
+	err := c.cc.Invoke(ctx, "/bass.Memos/Retrieve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memosClient) Remove(ctx context.Context, in *MemoKey, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/bass.Memos/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MemosServer is the server API for Memos service.
+// All implementations must embed UnimplementedMemosServer
+// for forward compatibility
+type MemosServer interface {
+	Store(context.Context, *StoreMemoRequest) (*emptypb.Empty, error)
+	Retrieve(context.Context, *MemoKey) (*RetrieveMemoResponse, error)
+	Remove(context.Context, *MemoKey) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMemosServer()
+}
+
+// UnimplementedMemosServer must be embedded to have forward compatible implementations.
+type UnimplementedMemosServer struct {
+}
+
+func (UnimplementedMemosServer) Store(context.Context, *StoreMemoRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Store not implemented")
+}
+func (UnimplementedMemosServer) Retrieve(context.Context, *MemoKey) (*RetrieveMemoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Retrieve not implemented")
+}
+func (UnimplementedMemosServer) Remove(context.Context, *MemoKey) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedMemosServer) mustEmbedUnimplementedMemosServer() {}
+
+// UnsafeMemosServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MemosServer will
+// result in compilation errors.
+type UnsafeMemosServer interface {
+	mustEmbedUnimplementedMemosServer()
+}
+
+func RegisterMemosServer(s grpc.ServiceRegistrar, srv MemosServer) {
+	s.RegisterService(&Memos_ServiceDesc, srv)
+}
+
+func _Memos_Store_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreMemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemosServer).Store(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bass.Memos/Store",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemosServer).Store(ctx, req.(*StoreMemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Memos_Retrieve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemosServer).Retrieve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bass.Memos/Retrieve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemosServer).Retrieve(ctx, req.(*MemoKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Memos_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemosServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bass.Memos/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemosServer).Remove(ctx, req.(*MemoKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Memos_ServiceDesc is the grpc.ServiceDesc for Memos service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Memos_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bass.Memos",
+	HandlerType: (*MemosServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Store",
+			Handler:    _Memos_Store_Handler,
+		},
+		{
+			MethodName: "Retrieve",
+			Handler:    _Memos_Retrieve_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _Memos_Remove_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "memo.proto",
+}
@@ -27,6 +27,9 @@ type RuntimeClient interface {
 	Read(ctx context.Context, in *Thunk, opts ...grpc.CallOption) (Runtime_ReadClient, error)
 	Export(ctx context.Context, in *Thunk, opts ...grpc.CallOption) (Runtime_ExportClient, error)
 	ExportPath(ctx context.Context, in *ThunkPath, opts ...grpc.CallOption) (Runtime_ExportPathClient, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (Runtime_PublishClient, error)
+	Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (Runtime_PruneClient, error)
+	DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error)
 }
 
 type runtimeClient struct {
@@ -174,6 +177,79 @@ func (x *runtimeExportPathClient) Recv() (*Bytes, error) {
 	return m, nil
 }
 
+func (c *runtimeClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (Runtime_PublishClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[4], "/bass.Runtime/Publish", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimePublishClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_PublishClient interface {
+	Recv() (*PublishResponse, error)
+	grpc.ClientStream
+}
+
+type runtimePublishClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimePublishClient) Recv() (*PublishResponse, error) {
+	m := new(PublishResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeClient) Prune(ctx context.Context, in *PruneRequest, opts ...grpc.CallOption) (Runtime_PruneClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Runtime_ServiceDesc.Streams[5], "/bass.Runtime/Prune", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimePruneClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Runtime_PruneClient interface {
+	Recv() (*PruneResponse, error)
+	grpc.ClientStream
+}
+
+type runtimePruneClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimePruneClient) Recv() (*PruneResponse, error) {
+	m := new(PruneResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeClient) DiskUsage(ctx context.Context, in *DiskUsageRequest, opts ...grpc.CallOption) (*DiskUsageResponse, error) {
+	out := new(DiskUsageResponse)
+	err := c.cc.Invoke(ctx, "/bass.Runtime/DiskUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuntimeServer is the server API for Runtime service.
 // All implementations must embed UnimplementedRuntimeServer
 // for forward compatibility
@@ -183,6 +259,9 @@ type RuntimeServer interface {
 	Read(*Thunk, Runtime_ReadServer) error
 	Export(*Thunk, Runtime_ExportServer) error
 	ExportPath(*ThunkPath, Runtime_ExportPathServer) error
+	Publish(*PublishRequest, Runtime_PublishServer) error
+	Prune(*PruneRequest, Runtime_PruneServer) error
+	DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error)
 	mustEmbedUnimplementedRuntimeServer()
 }
 
@@ -205,6 +284,15 @@ func (UnimplementedRuntimeServer) Export(*Thunk, Runtime_ExportServer) error {
 func (UnimplementedRuntimeServer) ExportPath(*ThunkPath, Runtime_ExportPathServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExportPath not implemented")
 }
+func (UnimplementedRuntimeServer) Publish(*PublishRequest, Runtime_PublishServer) error {
+	return status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedRuntimeServer) Prune(*PruneRequest, Runtime_PruneServer) error {
+	return status.Errorf(codes.Unimplemented, "method Prune not implemented")
+}
+func (UnimplementedRuntimeServer) DiskUsage(context.Context, *DiskUsageRequest) (*DiskUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiskUsage not implemented")
+}
 func (UnimplementedRuntimeServer) mustEmbedUnimplementedRuntimeServer() {}
 
 // UnsafeRuntimeServer may be embedded to opt out of forward compatibility for this service.
@@ -320,6 +408,66 @@ func (x *runtimeExportPathServer) Send(m *Bytes) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Runtime_Publish_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PublishRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).Publish(m, &runtimePublishServer{stream})
+}
+
+type Runtime_PublishServer interface {
+	Send(*PublishResponse) error
+	grpc.ServerStream
+}
+
+type runtimePublishServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimePublishServer) Send(m *PublishResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runtime_Prune_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PruneRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServer).Prune(m, &runtimePruneServer{stream})
+}
+
+type Runtime_PruneServer interface {
+	Send(*PruneResponse) error
+	grpc.ServerStream
+}
+
+type runtimePruneServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimePruneServer) Send(m *PruneResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Runtime_DiskUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServer).DiskUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bass.Runtime/DiskUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServer).DiskUsage(ctx, req.(*DiskUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Runtime_ServiceDesc is the grpc.ServiceDesc for Runtime service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -331,6 +479,10 @@ var Runtime_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Resolve",
 			Handler:    _Runtime_Resolve_Handler,
 		},
+		{
+			MethodName: "DiskUsage",
+			Handler:    _Runtime_DiskUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -353,6 +505,16 @@ var Runtime_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Runtime_ExportPath_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Publish",
+			Handler:       _Runtime_Publish_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Prune",
+			Handler:       _Runtime_Prune_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "runtime.proto",
 }
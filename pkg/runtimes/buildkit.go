@@ -24,13 +24,17 @@ import (
 	"github.com/moby/buildkit/client"
 	kitdclient "github.com/moby/buildkit/client"
 	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/exporter/containerimage/exptypes"
 	"github.com/moby/buildkit/frontend/dockerfile/dockerignore"
 	gwclient "github.com/moby/buildkit/frontend/gateway/client"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/entitlements"
 	"github.com/morikuni/aec"
+	"github.com/opencontainers/go-digest"
 	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/tonistiigi/units"
 	"github.com/vito/bass/pkg/bass"
@@ -44,8 +48,21 @@ import (
 
 const buildkitProduct = "bass"
 
+// dockerfileFrontend is Buildkit's built-in Dockerfile frontend, along with
+// the names of the inputs it reads the build context and Dockerfile from.
+const dockerfileFrontend = "dockerfile.v0"
+const dockerfileContextInput = "context"
+const dockerfileInput = "dockerfile"
+
 type BuildkitConfig struct {
 	DisableCache bool `json:"disable_cache,omitempty"`
+
+	// ImageEnv runs every thunk as though it had (with-image-env) set.
+	ImageEnv bool `json:"image_env,omitempty"`
+
+	// IsolatePinned runs thunks with no network access if all of their inputs
+	// are pinned by digest and they do not configure a network mode.
+	IsolatePinned bool `json:"isolate_pinned,omitempty"`
 }
 
 var _ bass.Runtime = &Buildkit{}
@@ -60,6 +77,7 @@ const workDir = "/bass/work"
 const ioDir = "/bass/io"
 const inputFile = "/bass/io/in"
 const outputFile = "/bass/io/out"
+const secretEnvPrefix = "_BASS_SECRET_"
 
 const digestBucket = "_digests"
 const configBucket = "_configs"
@@ -192,14 +210,15 @@ func (runtime *Buildkit) Resolve(ctx context.Context, imageRef bass.ThunkImageRe
 }
 
 func (runtime *Buildkit) Run(ctx context.Context, thunk bass.Thunk) error {
-	return runtime.build(
+	_, err := runtime.build(
 		ctx,
 		thunk,
 		false,
-		func(st llb.ExecState, _ string) marshalable {
-			return st.GetMount(ioDir)
+		func(_ context.Context, st llb.ExecState, _ string) (marshalable, error) {
+			return st.GetMount(ioDir), nil
 		},
 	)
+	return err
 }
 
 func (runtime *Buildkit) Read(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
@@ -215,11 +234,13 @@ func (runtime *Buildkit) Read(ctx context.Context, w io.Writer, thunk bass.Thunk
 
 	defer os.RemoveAll(tmp)
 
-	err = runtime.build(
+	_, err = runtime.build(
 		ctx,
 		thunk,
 		true,
-		func(st llb.ExecState, _ string) marshalable { return st.GetMount(ioDir) },
+		func(_ context.Context, st llb.ExecState, _ string) (marshalable, error) {
+			return st.GetMount(ioDir), nil
+		},
 		kitdclient.ExportEntry{
 			Type:      kitdclient.ExporterLocal,
 			OutputDir: tmp,
@@ -253,29 +274,141 @@ type marshalable interface {
 }
 
 func (runtime *Buildkit) Export(ctx context.Context, w io.Writer, thunk bass.Thunk) error {
+	_, err := runtime.buildImage(ctx, thunk, kitdclient.ExportEntry{
+		Type: kitdclient.ExporterOCI,
+		Output: func(map[string]string) (io.WriteCloser, error) {
+			return nopCloser{w}, nil
+		},
+	})
+	return err
+}
+
+func (runtime *Buildkit) Publish(ctx context.Context, ref bass.ThunkImageRef, thunk bass.Thunk) (bass.ThunkImageRef, error) {
+	name, err := ref.Ref()
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	res, err := runtime.buildImage(ctx, thunk, kitdclient.ExportEntry{
+		Type: kitdclient.ExporterImage,
+		Attrs: map[string]string{
+			"name": name,
+			"push": "true",
+		},
+	})
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	digest, found := res.ExporterResponse[exptypes.ExporterImageDigestKey]
+	if !found {
+		return bass.ThunkImageRef{}, fmt.Errorf("no digest in exporter response: %v", res.ExporterResponse)
+	}
+
+	ref.Digest = digest
+
+	return ref, nil
+}
+
+// buildImage builds the thunk and exports its rootfs as an image, configured
+// by the thunk's image config.
+func (runtime *Buildkit) buildImage(ctx context.Context, thunk bass.Thunk, export kitdclient.ExportEntry) (*kitdclient.SolveResponse, error) {
+	attrs := map[string]string{}
+	for k, v := range export.Attrs {
+		attrs[k] = v
+	}
+
+	export.Attrs = attrs
+
 	return runtime.build(
 		ctx,
 		thunk,
 		false,
-		func(st llb.ExecState, _ string) marshalable { return st },
-		kitdclient.ExportEntry{
-			Type: kitdclient.ExporterOCI,
-			Output: func(map[string]string) (io.WriteCloser, error) {
-				return nopCloser{w}, nil
-			},
+		func(ctx context.Context, st llb.ExecState, _ string) (marshalable, error) {
+			config, err := runtime.imageConfig(ctx, st.State, thunk.ImageConfig)
+			if err != nil {
+				return nil, fmt.Errorf("image config: %w", err)
+			}
+
+			// passed along to the exporter as image metadata
+			attrs[exptypes.ExporterImageConfigKey] = string(config)
+
+			return st, nil
 		},
+		export,
 	)
 }
 
+// imageConfig returns the OCI image config for an exported image, including
+// the environment of the state and the given config, if any.
+func (runtime *Buildkit) imageConfig(ctx context.Context, st llb.State, config *bass.ThunkImageConfig) ([]byte, error) {
+	env, err := st.Env(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	image := ocispecs.Image{
+		Architecture: runtime.Platform.Architecture,
+		OS:           runtime.Platform.OS,
+		Config: ocispecs.ImageConfig{
+			Env: env,
+		},
+	}
+
+	if config != nil {
+		configEnv, err := config.EnvStrings()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kv := range configEnv {
+			name, _, _ := strings.Cut(kv, "=")
+
+			// override any existing value from the base image
+			var merged []string
+			for _, existing := range image.Config.Env {
+				if !strings.HasPrefix(existing, name+"=") {
+					merged = append(merged, existing)
+				}
+			}
+
+			image.Config.Env = append(merged, kv)
+		}
+
+		labels, err := config.LabelMap()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(labels) > 0 {
+			image.Config.Labels = labels
+		}
+
+		image.Config.Entrypoint = config.Entrypoint
+		image.Config.Cmd = config.Cmd
+		image.Config.User = config.User
+
+		for _, port := range config.Ports() {
+			if image.Config.ExposedPorts == nil {
+				image.Config.ExposedPorts = map[string]struct{}{}
+			}
+
+			image.Config.ExposedPorts[port] = struct{}{}
+		}
+	}
+
+	return json.Marshal(image)
+}
+
 func (runtime *Buildkit) ExportPath(ctx context.Context, w io.Writer, tp bass.ThunkPath) error {
 	thunk := tp.Thunk
 	path := tp.Path
 
-	return runtime.build(
+	_, err := runtime.build(
 		ctx,
 		thunk,
 		false,
-		func(st llb.ExecState, sp string) marshalable {
+		func(_ context.Context, st llb.ExecState, sp string) (marshalable, error) {
 			copyOpt := &llb.CopyInfo{}
 			if path.FilesystemPath().IsDir() {
 				copyOpt.CopyDirContentsOnly = true
@@ -284,7 +417,7 @@ func (runtime *Buildkit) ExportPath(ctx context.Context, w io.Writer, tp bass.Th
 			return llb.Scratch().File(
 				llb.Copy(st.GetMount(workDir), filepath.Join(sp, path.FilesystemPath().FromSlash()), ".", copyOpt),
 				llb.WithCustomNamef("[hide] copy %s", path.Slash()),
-			)
+			), nil
 		},
 		kitdclient.ExportEntry{
 			Type: kitdclient.ExporterTar,
@@ -293,6 +426,7 @@ func (runtime *Buildkit) ExportPath(ctx context.Context, w io.Writer, tp bass.Th
 			},
 		},
 	)
+	return err
 }
 
 func (runtime *Buildkit) Prune(ctx context.Context, opts bass.PruneOpts) error {
@@ -342,19 +476,44 @@ func (runtime *Buildkit) Prune(ctx context.Context, opts bass.PruneOpts) error {
 	return tw.Flush()
 }
 
+func (runtime *Buildkit) DiskUsage(ctx context.Context) ([]bass.DiskUsage, error) {
+	infos, err := runtime.Client.DiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]bass.DiskUsage, len(infos))
+	for i, info := range infos {
+		usage[i] = bass.DiskUsage{
+			ID:          info.ID,
+			Description: info.Description,
+			Size:        info.Size,
+			UsageCount:  info.UsageCount,
+			LastUsedAt:  info.LastUsedAt,
+		}
+	}
+
+	return usage, nil
+}
+
 func (runtime *Buildkit) Close() error {
 	return runtime.Client.Close()
 }
 
-func (runtime *Buildkit) build(ctx context.Context, thunk bass.Thunk, captureStdout bool, transform func(llb.ExecState, string) marshalable, exports ...kitdclient.ExportEntry) error {
+func (runtime *Buildkit) build(ctx context.Context, thunk bass.Thunk, captureStdout bool, transform func(context.Context, llb.ExecState, string) (marshalable, error), exports ...kitdclient.ExportEntry) (*kitdclient.SolveResponse, error) {
 	var def *llb.Definition
-	var secrets map[string][]byte
+	var attachables []session.Attachable
 	var localDirs map[string]string
 	var allowed []entitlements.Entitlement
+	var thunks map[string]bass.Thunk
 
 	statusProxy := forwardStatus(progrock.RecorderFromContext(ctx))
 	defer statusProxy.Wait()
 
+	if bass.ExplainingCache(ctx) {
+		statusProxy.RecordVertexes()
+	}
+
 	// build llb definition using the remote gateway for image resolution
 	_, err := runtime.Client.Build(ctx, kitdclient.SolveOpt{
 		Session: []session.Attachable{runtime.authp},
@@ -366,14 +525,22 @@ func (runtime *Buildkit) build(ctx context.Context, thunk bass.Thunk, captureStd
 			return nil, err
 		}
 
-		if needsInsecure {
-			allowed = append(allowed, entitlements.EntitlementSecurityInsecure)
+		allowed = b.entitlements(needsInsecure)
+
+		attachables, err = b.attachables()
+		if err != nil {
+			return nil, err
 		}
 
 		localDirs = b.localDirs
-		secrets = b.secrets
+		thunks = b.thunks
+
+		m, err := transform(ctx, st, sp)
+		if err != nil {
+			return nil, err
+		}
 
-		def, err = transform(st, sp).Marshal(ctx)
+		def, err = m.Marshal(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -381,23 +548,56 @@ func (runtime *Buildkit) build(ctx context.Context, thunk bass.Thunk, captureStd
 		return &gwclient.Result{}, nil
 	}, statusProxy.Writer())
 	if err != nil {
-		return statusProxy.NiceError("llb build failed", err)
+		return nil, statusProxy.NiceError("llb build failed", err)
 	}
 
-	_, err = runtime.Client.Solve(ctx, def, kitdclient.SolveOpt{
+	res, err := runtime.Client.Solve(ctx, def, kitdclient.SolveOpt{
 		LocalDirs:           localDirs,
 		AllowedEntitlements: allowed,
-		Session: []session.Attachable{
-			runtime.authp,
-			secretsprovider.FromMap(secrets),
-		},
-		Exports: exports,
+		Session:             attachables,
+		Exports:             exports,
 	}, statusProxy.Writer())
+
+	statusProxy.Wait()
+
+	if bass.ExplainingCache(ctx) {
+		explainCache(ctx, def, thunks, statusProxy)
+	}
+
 	if err != nil {
-		return statusProxy.NiceError("build failed", err)
+		return nil, statusProxy.NiceError("build failed", err)
 	}
 
-	return nil
+	return res, nil
+}
+
+// explainCache reports whether each thunk's exec op was cached, identifying
+// them by hostname.
+func explainCache(ctx context.Context, def *llb.Definition, thunks map[string]bass.Thunk, statusProxy *statusProxy) {
+	for _, dt := range def.Def {
+		var op pb.Op
+		if err := op.Unmarshal(dt); err != nil {
+			continue
+		}
+
+		exec := op.GetExec()
+		if exec == nil || exec.Meta == nil {
+			continue
+		}
+
+		thunk, found := thunks[exec.Meta.Hostname]
+		if !found {
+			continue
+		}
+
+		vtx, found := statusProxy.Vertex(digest.FromBytes(dt))
+		if !found || (vtx.Completed == nil && !vtx.Cached) {
+			// never started, e.g. due to an earlier failure
+			continue
+		}
+
+		bass.ExplainCache(ctx, thunk, vtx.Cached)
+	}
 }
 
 type builder struct {
@@ -406,6 +606,15 @@ type builder struct {
 
 	secrets   map[string][]byte
 	localDirs map[string]string
+
+	// set when any thunk runs in the host's network namespace
+	needsHostNetwork bool
+
+	// set when any thunk forwards the host's SSH agent
+	needsSSH bool
+
+	// each thunk that is run, by the hostname of its exec op
+	thunks map[string]bass.Thunk
 }
 
 func (runtime *Buildkit) newBuilder(resolver llb.ImageMetaResolver) *builder {
@@ -415,10 +624,23 @@ func (runtime *Buildkit) newBuilder(resolver llb.ImageMetaResolver) *builder {
 
 		secrets:   map[string][]byte{},
 		localDirs: map[string]string{},
+		thunks:    map[string]bass.Thunk{},
 	}
 }
 
 func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool) (llb.ExecState, string, bool, error) {
+	// thunks may have been decoded from JSON, bypassing the checks in
+	// (with-capabilities) and (with-device)
+	if err := bass.PrivilegesFromContext(ctx).CheckThunk(thunk); err != nil {
+		return llb.ExecState{}, "", false, err
+	}
+
+	// Buildkit can only grant every capability and device at once, which is
+	// what (with-insecure) is for
+	if !thunk.Insecure && (len(thunk.Capabilities) > 0 || len(thunk.Devices) > 0) {
+		return llb.ExecState{}, "", false, fmt.Errorf("capabilities and devices are unsupported by the %s runtime: %s", BuildkitName, thunk)
+	}
+
 	cmd, err := NewCommand(thunk)
 	if err != nil {
 		return llb.ExecState{}, "", false, err
@@ -434,6 +656,24 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 		return llb.ExecState{}, "", false, err
 	}
 
+	b.thunks[id] = thunk
+
+	if thunk.ImageEnv || b.runtime.Config.ImageEnv {
+		config, err := b.imageConfig(ctx, imageRef, thunk.Image)
+		if err != nil {
+			return llb.ExecState{}, "", false, fmt.Errorf("image config: %w", err)
+		}
+
+		cmd.Args = append(append([]string{}, config.Entrypoint...), cmd.Args...)
+
+		if cmd.User == "" {
+			cmd.User = config.User
+		}
+
+		// NB: config.WorkingDir is deliberately ignored; the command's thunk
+		// paths are relative to workDir, and its outputs are written there
+	}
+
 	cmdPayload, err := bass.MarshalJSON(cmd)
 	if err != nil {
 		return llb.ExecState{}, "", false, err
@@ -444,12 +684,24 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 		return llb.ExecState{}, "", false, err
 	}
 
+	var tmpfsOpts []llb.TmpfsOption
+	if thunk.Limits != nil {
+		size, err := thunk.Limits.TmpfsSizeBytes()
+		if err != nil {
+			return llb.ExecState{}, "", false, err
+		}
+
+		if size > 0 {
+			tmpfsOpts = append(tmpfsOpts, llb.TmpfsSize(size))
+		}
+	}
+
 	runOpt := []llb.RunOption{
 		llb.WithCustomName(thunk.Cmdline()),
 		// NB: this is load-bearing; it's what busts the cache with different labels
 		llb.Hostname(id),
-		llb.AddMount("/tmp", llb.Scratch(), llb.Tmpfs()),
-		llb.AddMount("/dev/shm", llb.Scratch(), llb.Tmpfs()),
+		llb.AddMount("/tmp", llb.Scratch(), llb.Tmpfs(tmpfsOpts...)),
+		llb.AddMount("/dev/shm", llb.Scratch(), llb.Tmpfs(tmpfsOpts...)),
 		llb.AddMount(ioDir, llb.Scratch().File(
 			llb.Mkfile("in", 0600, cmdPayload),
 			llb.WithCustomName("[hide] mount command json"),
@@ -463,6 +715,24 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 		runOpt = append(runOpt, llb.AddEnv("_BASS_OUTPUT", outputFile))
 	}
 
+	for _, env := range cmd.SecretEnv {
+		id := env.Secret.Name
+		b.secrets[id] = env.Secret.Reveal()
+
+		// the shim moves it to the real env var right before running the
+		// command, so the value never touches the command json
+		runOpt = append(runOpt, llb.AddSecret(secretEnvPrefix+env.Name,
+			llb.SecretID(id),
+			llb.SecretAsEnv(true)))
+	}
+
+	if thunk.SSH {
+		b.needsSSH = true
+
+		// sets $SSH_AUTH_SOCK
+		runOpt = append(runOpt, llb.AddSSHSocket())
+	}
+
 	if thunk.Insecure {
 		needsInsecure = true
 
@@ -471,6 +741,22 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 			llb.Security(llb.SecurityModeInsecure))
 	}
 
+	network := thunk.Network
+	if network == "" && b.runtime.Config.IsolatePinned && thunk.Pinned() {
+		network = bass.NetworkNone
+	}
+
+	switch network {
+	case bass.NetworkNone:
+		runOpt = append(runOpt, llb.Network(llb.NetModeNone))
+	case bass.NetworkHost:
+		b.needsHostNetwork = true
+		runOpt = append(runOpt, llb.Network(llb.NetModeHost))
+	case bass.NetworkDefault, "":
+	default:
+		return llb.ExecState{}, "", false, fmt.Errorf("unknown network mode: %s", network)
+	}
+
 	var remountedWorkdir bool
 	for _, mount := range cmd.Mounts {
 		var targetPath string
@@ -480,7 +766,7 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 			targetPath = filepath.Join(workDir, mount.Target)
 		}
 
-		mountOpt, sp, ni, err := b.initializeMount(ctx, mount.Source, targetPath)
+		mountOpt, sp, ni, err := b.initializeMount(ctx, mount, targetPath)
 		if err != nil {
 			return llb.ExecState{}, "", false, err
 		}
@@ -514,6 +800,49 @@ func (b *builder) llb(ctx context.Context, thunk bass.Thunk, captureStdout bool)
 	return imageRef.Run(runOpt...), sourcePath, needsInsecure, nil
 }
 
+// entitlements returns the entitlements to allow when solving states built
+// by the builder.
+func (b *builder) entitlements(needsInsecure bool) []entitlements.Entitlement {
+	var allowed []entitlements.Entitlement
+	if needsInsecure {
+		allowed = append(allowed, entitlements.EntitlementSecurityInsecure)
+	}
+
+	if b.needsHostNetwork {
+		allowed = append(allowed, entitlements.EntitlementNetworkHost)
+	}
+
+	return allowed
+}
+
+// attachables returns the session attachables to use when solving states
+// built by the builder.
+func (b *builder) attachables() ([]session.Attachable, error) {
+	attachables := []session.Attachable{
+		b.runtime.authp,
+		secretsprovider.FromMap(b.secrets),
+	}
+
+	if b.needsSSH {
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			return nil, fmt.Errorf("cannot forward SSH agent: $SSH_AUTH_SOCK is not set")
+		}
+
+		// NB: the socket path is only known to the session, not the LLB, so it
+		// does not affect caching
+		ssh, err := sshprovider.NewSSHAgentProvider([]sshprovider.AgentConfig{
+			{ID: "default"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("forward SSH agent: %w", err)
+		}
+
+		attachables = append(attachables, ssh)
+	}
+
+	return attachables, nil
+}
+
 func (b *builder) shim() (llb.State, error) {
 	shimExe, found := allShims["exe."+b.runtime.Platform.Architecture]
 	if !found {
@@ -558,9 +887,208 @@ func (b *builder) imageRef(ctx context.Context, image *bass.ThunkImage) (llb.Sta
 		return execState.State, execState.GetMount(workDir), sourcePath, needsInsecure, nil
 	}
 
+	if image.DockerBuild != nil {
+		return b.dockerBuild(ctx, *image.DockerBuild)
+	}
+
 	return llb.State{}, llb.State{}, "", false, fmt.Errorf("unsupported image type: %+v", image)
 }
 
+// imageConfigKey is the llb.State value key for the OCI image config of images
+// which are not resolved from a registry, i.e. OCI archives and Dockerfiles.
+type imageConfigKey struct{}
+
+// imageConfig returns the OCI image config for the given image, whose state
+// is st.
+func (b *builder) imageConfig(ctx context.Context, st llb.State, image *bass.ThunkImage) (ocispecs.ImageConfig, error) {
+	val, err := st.Value(ctx, imageConfigKey{})
+	if err != nil {
+		return ocispecs.ImageConfig{}, err
+	}
+
+	if config, ok := val.(ocispecs.ImageConfig); ok {
+		return config, nil
+	}
+
+	switch {
+	case image == nil:
+		return ocispecs.ImageConfig{}, nil
+	case image.Ref != nil:
+		ref, err := image.Ref.Ref()
+		if err != nil {
+			return ocispecs.ImageConfig{}, err
+		}
+
+		_, dt, err := b.resolver.ResolveImageConfig(ctx, ref, llb.ResolveImageConfigOpt{
+			Platform:    &b.runtime.Platform,
+			ResolveMode: llb.ResolveModeDefault.String(),
+		})
+		if err != nil {
+			return ocispecs.ImageConfig{}, err
+		}
+
+		var img ocispecs.Image
+		err = json.Unmarshal(dt, &img)
+		if err != nil {
+			return ocispecs.ImageConfig{}, fmt.Errorf("unmarshal image config: %w", err)
+		}
+
+		return img.Config, nil
+	case image.Thunk != nil:
+		return b.imageConfig(ctx, st, image.Thunk.Image)
+	default:
+		return ocispecs.ImageConfig{}, nil
+	}
+}
+
+func (b *builder) dockerBuild(ctx context.Context, build bass.ThunkDockerBuild) (llb.State, llb.State, string, bool, error) {
+	contextSt, needsInsecure, err := b.buildContext(ctx, build.Context)
+	if err != nil {
+		return llb.State{}, llb.State{}, "", false, fmt.Errorf("build context: %w", err)
+	}
+
+	args, err := build.BuildArgs()
+	if err != nil {
+		return llb.State{}, llb.State{}, "", false, err
+	}
+
+	platform := b.runtime.Platform
+	platform.OS = build.Platform.OS
+	if build.Platform.Arch != "" {
+		platform.Architecture = build.Platform.Arch
+	}
+
+	opts := map[string]string{
+		"filename": build.DockerfilePath(),
+		"platform": platforms.Format(platform),
+	}
+
+	if build.Target != "" {
+		opts["target"] = build.Target
+	}
+
+	for name, val := range args {
+		opts["build-arg:"+name] = val
+	}
+
+	st, config, err := b.solveDockerfile(ctx, contextSt, opts, needsInsecure)
+	if err != nil {
+		return llb.State{}, llb.State{}, "", false, err
+	}
+
+	// the shim must run as root; the image's user is applied by the shim when
+	// the thunk runs with the image env
+	image := st.User("").WithValue(imageConfigKey{}, config)
+
+	return image, llb.Scratch(), "", needsInsecure, nil
+}
+
+// buildContext returns a state containing the content of the build context
+// directory at its root.
+func (b *builder) buildContext(ctx context.Context, input bass.ImageBuildInput) (llb.State, bool, error) {
+	var st llb.State
+	var sourcePath string
+	var needsInsecure bool
+
+	switch {
+	case input.Thunk != nil:
+		thunkSt, baseSourcePath, ni, err := b.llb(ctx, input.Thunk.Thunk, false)
+		if err != nil {
+			return llb.State{}, false, fmt.Errorf("thunk llb: %w", err)
+		}
+
+		st = thunkSt.GetMount(workDir)
+		sourcePath = filepath.Join(baseSourcePath, input.Thunk.Path.FilesystemPath().FromSlash())
+		needsInsecure = ni
+	case input.Host != nil:
+		hostSt, sp, err := b.hostPath(*input.Host)
+		if err != nil {
+			return llb.State{}, false, err
+		}
+
+		st = hostSt
+		sourcePath = sp
+	case input.FS != nil:
+		tree, err := fsDirTree(*input.FS)
+		if err != nil {
+			return llb.State{}, false, err
+		}
+
+		st = tree
+		sourcePath = input.FS.Path.FilesystemPath().FromSlash()
+	default:
+		return llb.State{}, false, fmt.Errorf("unrecognized build context: %s", input.ToValue())
+	}
+
+	return llb.Scratch().File(llb.Copy(st, sourcePath, "/", &llb.CopyInfo{
+		CopyDirContentsOnly: true,
+	})), needsInsecure, nil
+}
+
+// solveDockerfile solves the Dockerfile in the build context using
+// Buildkit's Dockerfile frontend, returning the resulting image state and
+// config.
+func (b *builder) solveDockerfile(ctx context.Context, contextSt llb.State, opts map[string]string, needsInsecure bool) (llb.State, ocispecs.ImageConfig, error) {
+	allowed := b.entitlements(needsInsecure)
+
+	attachables, err := b.attachables()
+	if err != nil {
+		return llb.State{}, ocispecs.ImageConfig{}, err
+	}
+
+	statusProxy := forwardStatus(progrock.RecorderFromContext(ctx))
+	defer statusProxy.Wait()
+
+	var st llb.State
+	var img ocispecs.Image
+	_, err = b.runtime.Client.Build(ctx, kitdclient.SolveOpt{
+		LocalDirs:           b.localDirs,
+		AllowedEntitlements: allowed,
+		Session:             attachables,
+	}, buildkitProduct, func(ctx context.Context, gw gwclient.Client) (*gwclient.Result, error) {
+		def, err := contextSt.Marshal(ctx, llb.WithCaps(gw.BuildOpts().LLBCaps))
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := gw.Solve(ctx, gwclient.SolveRequest{
+			Frontend:    dockerfileFrontend,
+			FrontendOpt: opts,
+			FrontendInputs: map[string]*pb.Definition{
+				dockerfileContextInput: def.ToPB(),
+				dockerfileInput:        def.ToPB(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		singleRef, err := res.SingleRef()
+		if err != nil {
+			return nil, fmt.Errorf("get single ref: %w", err)
+		}
+
+		st, err = singleRef.ToState()
+		if err != nil {
+			return nil, fmt.Errorf("ref state: %w", err)
+		}
+
+		if config, found := res.Metadata[exptypes.ExporterImageConfigKey]; found {
+			err = json.Unmarshal(config, &img)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshal image config: %w", err)
+			}
+		}
+
+		return &gwclient.Result{}, nil
+	}, statusProxy.Writer())
+	if err != nil {
+		return llb.State{}, ocispecs.ImageConfig{}, statusProxy.NiceError("dockerfile build failed", err)
+	}
+
+	return st, img.Config, nil
+}
+
 func (b *builder) unpackImageArchive(ctx context.Context, thunkPath bass.ThunkPath, tag string) (llb.State, llb.State, string, bool, error) {
 	shimExe, err := b.shim()
 	if err != nil {
@@ -598,9 +1126,11 @@ func (b *builder) unpackImageArchive(ctx context.Context, thunkPath bass.ThunkPa
 
 	image := unpackSt.GetMount("/rootfs")
 
-	var allowed []entitlements.Entitlement
-	if needsInsecure {
-		allowed = append(allowed, entitlements.EntitlementSecurityInsecure)
+	allowed := b.entitlements(needsInsecure)
+
+	attachables, err := b.attachables()
+	if err != nil {
+		return llb.State{}, llb.State{}, "", false, err
 	}
 
 	statusProxy := forwardStatus(progrock.RecorderFromContext(ctx))
@@ -609,10 +1139,7 @@ func (b *builder) unpackImageArchive(ctx context.Context, thunkPath bass.ThunkPa
 	_, err = b.runtime.Client.Build(ctx, kitdclient.SolveOpt{
 		LocalDirs:           b.localDirs,
 		AllowedEntitlements: allowed,
-		Session: []session.Attachable{
-			b.runtime.authp,
-			secretsprovider.FromMap(b.secrets),
-		},
+		Session:             attachables,
 	}, buildkitProduct, func(ctx context.Context, gw gwclient.Client) (*gwclient.Result, error) {
 		def, err := configSt.GetMount("/config").Marshal(ctx, llb.WithCaps(gw.BuildOpts().LLBCaps))
 		if err != nil {
@@ -653,6 +1180,8 @@ func (b *builder) unpackImageArchive(ctx context.Context, thunkPath bass.ThunkPa
 			}
 		}
 
+		image = image.WithValue(imageConfigKey{}, iconf)
+
 		return &gwclient.Result{}, nil
 	}, statusProxy.Writer())
 	if err != nil {
@@ -662,7 +1191,22 @@ func (b *builder) unpackImageArchive(ctx context.Context, thunkPath bass.ThunkPa
 	return image, llb.Scratch(), "", needsInsecure, nil
 }
 
-func (b *builder) initializeMount(ctx context.Context, source bass.ThunkMountSource, targetPath string) (llb.RunOption, string, bool, error) {
+func (b *builder) initializeMount(ctx context.Context, mount CommandMount, targetPath string) (llb.RunOption, string, bool, error) {
+	source := mount.Source
+
+	var chown *llb.ChownOpt
+	if mount.Owner != "" {
+		uid, gid, err := bass.ParseOwner(mount.Owner)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		chown = &llb.ChownOpt{
+			User:  &llb.UserOpt{UID: uid},
+			Group: &llb.UserOpt{UID: gid},
+		}
+	}
+
 	if source.ThunkPath != nil {
 		thunkSt, baseSourcePath, needsInsecure, err := b.llb(ctx, source.ThunkPath.Thunk, false)
 		if err != nil {
@@ -671,44 +1215,26 @@ func (b *builder) initializeMount(ctx context.Context, source bass.ThunkMountSou
 
 		sourcePath := filepath.Join(baseSourcePath, source.ThunkPath.Path.FilesystemPath().FromSlash())
 
+		st, sourcePath := ownedMount(thunkSt.GetMount(workDir), sourcePath, source.ThunkPath.Path.FilesystemPath().IsDir(), chown)
+
 		return llb.AddMount(
 			targetPath,
-			thunkSt.GetMount(workDir),
+			st,
 			llb.SourcePath(sourcePath),
 		), sourcePath, needsInsecure, nil
 	}
 
 	if source.HostPath != nil {
-		contextDir := source.HostPath.ContextDir
-		b.localDirs[contextDir] = source.HostPath.ContextDir
-
-		var excludes []string
-		ignorePath := filepath.Join(contextDir, ".bassignore")
-		ignore, err := os.Open(ignorePath)
-		if err == nil {
-			excludes, err = dockerignore.ReadAll(ignore)
-			if err != nil {
-				return nil, "", false, fmt.Errorf("parse %s: %w", ignorePath, err)
-			}
+		hostSt, sourcePath, err := b.hostPath(*source.HostPath)
+		if err != nil {
+			return nil, "", false, err
 		}
 
-		sourcePath := source.HostPath.Path.FilesystemPath().FromSlash()
+		st, sourcePath := ownedMount(hostSt, sourcePath, source.HostPath.Path.FilesystemPath().IsDir(), chown)
 
 		return llb.AddMount(
 			targetPath,
-			llb.Scratch().File(llb.Copy(
-				llb.Local(
-					contextDir,
-					llb.ExcludePatterns(excludes),
-					llb.Differ(llb.DiffMetadata, false),
-				),
-				sourcePath, // allow fine-grained caching control
-				sourcePath,
-				&llb.CopyInfo{
-					CopyDirContentsOnly: true,
-					CreateDestPath:      true,
-				},
-			)),
+			st,
 			llb.SourcePath(sourcePath),
 		), sourcePath, false, nil
 	}
@@ -730,56 +1256,37 @@ func (b *builder) initializeMount(ctx context.Context, source bass.ThunkMountSou
 				tree = tree.File(llb.Mkdir(path.Dir(filePath), 0755, llb.WithParents(true)))
 			}
 
+			mkfileOpts := []llb.MkfileOption{}
+			if chown != nil {
+				mkfileOpts = append(mkfileOpts, chown)
+			}
+
 			return llb.AddMount(
 				targetPath,
-				tree.File(llb.Mkfile(filePath, 0644, content)),
+				tree.File(llb.Mkfile(filePath, 0644, content, mkfileOpts...)),
 				llb.SourcePath(sourcePath),
 			), sourcePath, false, nil
 		} else {
-			tree := llb.Scratch()
-
-			err := fs.WalkDir(fsp.FS, path.Clean(fsp.Path.Slash()), func(walkPath string, d fs.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
-
-				info, err := d.Info()
-				if err != nil {
-					return err
-				}
-
-				if d.IsDir() {
-					tree = tree.File(llb.Mkdir(walkPath, info.Mode(), llb.WithParents(true)))
-				} else {
-					content, err := fs.ReadFile(fsp.FS, walkPath)
-					if err != nil {
-						return fmt.Errorf("read %s: %w", walkPath, err)
-					}
-
-					if strings.Contains(walkPath, "/") {
-						tree = tree.File(
-							llb.Mkdir(path.Dir(walkPath), 0755, llb.WithParents(true)),
-						)
-					}
-
-					tree = tree.File(llb.Mkfile(walkPath, info.Mode(), content))
-				}
-
-				return nil
-			})
+			tree, err := fsDirTree(*fsp)
 			if err != nil {
-				return nil, "", false, fmt.Errorf("walk %s: %w", fsp, err)
+				return nil, "", false, err
 			}
 
+			st, sourcePath := ownedMount(tree, sourcePath, true, chown)
+
 			return llb.AddMount(
 				targetPath,
-				tree,
+				st,
 				llb.SourcePath(sourcePath),
 			), sourcePath, false, nil
 		}
 	}
 
 	if source.Cache != nil {
+		if chown != nil {
+			return nil, "", false, fmt.Errorf("cannot set owner of cache mount: %s", source.Cache.Slash())
+		}
+
 		return llb.AddMount(
 			targetPath,
 			llb.Scratch(),
@@ -790,12 +1297,116 @@ func (b *builder) initializeMount(ctx context.Context, source bass.ThunkMountSou
 	if source.Secret != nil {
 		id := source.Secret.Name
 		b.secrets[id] = source.Secret.Reveal()
-		return llb.AddSecret(targetPath, llb.SecretID(id)), "", false, nil
+
+		secretOpts := []llb.SecretOption{llb.SecretID(id)}
+		if chown != nil {
+			secretOpts = append(secretOpts, llb.SecretFileOpt(chown.User.UID, chown.Group.UID, 0400))
+		}
+
+		return llb.AddSecret(targetPath, secretOpts...), "", false, nil
 	}
 
 	return nil, "", false, fmt.Errorf("unrecognized mount source: %s", source.ToValue())
 }
 
+// ownedMount returns the state and source path to mount for the content at
+// sourcePath in st. If chown is set, the content is copied so that it can be
+// owned by the given user and group.
+func ownedMount(st llb.State, sourcePath string, isDir bool, chown *llb.ChownOpt) (llb.State, string) {
+	if chown == nil {
+		return st, sourcePath
+	}
+
+	copyInfo := &llb.CopyInfo{
+		CopyDirContentsOnly: isDir,
+		CreateDestPath:      true,
+		ChownOpt:            chown,
+	}
+
+	if isDir {
+		// copy into a subdirectory so that the directory itself is created with
+		// the owner, allowing the owner to write to it
+		return llb.Scratch().File(llb.Copy(st, sourcePath, "/mount", copyInfo)), "/mount"
+	}
+
+	dest := "/" + filepath.Base(sourcePath)
+	return llb.Scratch().File(llb.Copy(st, sourcePath, dest, copyInfo)), dest
+}
+
+// hostPath returns a state containing the host path at its path relative to
+// its context directory, excluding any paths matched by .bassignore.
+func (b *builder) hostPath(host bass.HostPath) (llb.State, string, error) {
+	contextDir := host.ContextDir
+	b.localDirs[contextDir] = host.ContextDir
+
+	var excludes []string
+	ignorePath := filepath.Join(contextDir, ".bassignore")
+	ignore, err := os.Open(ignorePath)
+	if err == nil {
+		excludes, err = dockerignore.ReadAll(ignore)
+		if err != nil {
+			return llb.State{}, "", fmt.Errorf("parse %s: %w", ignorePath, err)
+		}
+	}
+
+	sourcePath := host.Path.FilesystemPath().FromSlash()
+
+	return llb.Scratch().File(llb.Copy(
+		llb.Local(
+			contextDir,
+			llb.ExcludePatterns(excludes),
+			llb.Differ(llb.DiffMetadata, false),
+		),
+		sourcePath, // allow fine-grained caching control
+		sourcePath,
+		&llb.CopyInfo{
+			CopyDirContentsOnly: true,
+			CreateDestPath:      true,
+		},
+	)), sourcePath, nil
+}
+
+// fsDirTree returns a state containing each file in the embedded directory at
+// its path in the filesystem.
+func fsDirTree(fsp bass.FSPath) (llb.State, error) {
+	tree := llb.Scratch()
+
+	err := fs.WalkDir(fsp.FS, path.Clean(fsp.Path.Slash()), func(walkPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			tree = tree.File(llb.Mkdir(walkPath, info.Mode(), llb.WithParents(true)))
+		} else {
+			content, err := fs.ReadFile(fsp.FS, walkPath)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", walkPath, err)
+			}
+
+			if strings.Contains(walkPath, "/") {
+				tree = tree.File(
+					llb.Mkdir(path.Dir(walkPath), 0755, llb.WithParents(true)),
+				)
+			}
+
+			tree = tree.File(llb.Mkfile(walkPath, info.Mode(), content))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return llb.State{}, fmt.Errorf("walk %s: %w", fsp, err)
+	}
+
+	return tree, nil
+}
+
 func hash(s string) string {
 	sum := sha256.Sum256([]byte(s))
 	return base64.URLEncoding.EncodeToString(sum[:])
@@ -822,6 +1433,11 @@ type statusProxy struct {
 	rec  *progrock.Recorder
 	wg   *sync.WaitGroup
 	prog *cli.Progress
+
+	// the latest status of each vertex, for explaining cache misses; only
+	// recorded once RecordVertexes is called
+	vertexes  map[digest.Digest]*kitdclient.Vertex
+	vertexesL sync.Mutex
 }
 
 func (proxy *statusProxy) proxy(rec *progrock.Recorder, statuses chan *kitdclient.SolveStatus) {
@@ -831,6 +1447,8 @@ func (proxy *statusProxy) proxy(rec *progrock.Recorder, statuses chan *kitdclien
 			break
 		}
 
+		proxy.recordVertexes(s.Vertexes)
+
 		vs := make([]*graph.Vertex, len(s.Vertexes))
 		for i, v := range s.Vertexes {
 			// TODO: we have strayed from upstream Buildkit, and it's tricky to
@@ -879,6 +1497,39 @@ func (proxy *statusProxy) Writer() chan *kitdclient.SolveStatus {
 	return statuses
 }
 
+// RecordVertexes starts recording the latest status of each vertex so that it
+// can be fetched with Vertex.
+func (proxy *statusProxy) RecordVertexes() {
+	proxy.vertexesL.Lock()
+	defer proxy.vertexesL.Unlock()
+
+	if proxy.vertexes == nil {
+		proxy.vertexes = map[digest.Digest]*kitdclient.Vertex{}
+	}
+}
+
+// Vertex returns the latest status of the vertex.
+func (proxy *statusProxy) Vertex(dig digest.Digest) (*kitdclient.Vertex, bool) {
+	proxy.vertexesL.Lock()
+	defer proxy.vertexesL.Unlock()
+
+	vtx, found := proxy.vertexes[dig]
+	return vtx, found
+}
+
+func (proxy *statusProxy) recordVertexes(vtxs []*kitdclient.Vertex) {
+	proxy.vertexesL.Lock()
+	defer proxy.vertexesL.Unlock()
+
+	if proxy.vertexes == nil {
+		return
+	}
+
+	for _, v := range vtxs {
+		proxy.vertexes[v.Digest] = v
+	}
+}
+
 func (proxy *statusProxy) Wait() {
 	proxy.wg.Wait()
 }
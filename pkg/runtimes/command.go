@@ -20,16 +20,38 @@ type Command struct {
 	Stdin []byte   `json:"stdin"`
 	Env   []string `json:"env"`
 	Dir   *string  `json:"dir"`
+	User  string   `json:"user,omitempty"`
+
+	Limits    *CommandLimits     `json:"limits,omitempty"`
+	SecretEnv []CommandSecretEnv `json:"secret_env,omitempty"`
 
 	Mounts []CommandMount `json:"-"` // doesn't need to be marshaled
 
 	mounted map[string]bool
 }
 
+// CommandLimits configures resource limits to be enforced by the shim.
+type CommandLimits struct {
+	Memory int64 `json:"memory,omitempty"`
+	CPUs   int   `json:"cpus,omitempty"`
+	Pids   int   `json:"pids,omitempty"`
+}
+
+// CommandSecretEnv is an env var whose value is a secret. The runtime passes
+// the secret to the shim out-of-band, and the shim sets the env var right
+// before running the command.
+type CommandSecretEnv struct {
+	Name   string      `json:"name"`
+	Secret bass.Secret `json:"-"` // never marshaled
+}
+
 // CommandMount configures a thunk path to mount to the command's container.
 type CommandMount struct {
 	Source bass.ThunkMountSource
 	Target string
+
+	// Owner is the numeric uid[:gid] to own the mounted content, if set.
+	Owner string
 }
 
 // StrThunk contains a list of values to be resolved to strings and
@@ -45,6 +67,7 @@ type StrThunk struct {
 // along the way.
 func NewCommand(thunk bass.Thunk) (Command, error) {
 	cmd := &Command{
+		User:    thunk.User,
 		mounted: map[string]bool{},
 	}
 
@@ -79,6 +102,19 @@ func NewCommand(thunk bass.Thunk) (Command, error) {
 
 	if thunk.Env != nil {
 		err := thunk.Env.Each(func(name bass.Symbol, v bass.Value) error {
+			var secret bass.Secret
+			if err := v.Decode(&secret); err == nil {
+				if secret.Reveal() == nil {
+					return fmt.Errorf("resolve env %s: missing secret: %s", name, secret.Name)
+				}
+
+				cmd.SecretEnv = append(cmd.SecretEnv, CommandSecretEnv{
+					Name:   name.JSONKey(),
+					Secret: secret,
+				})
+				return nil
+			}
+
 			val, err := cmd.resolveStr(v)
 			if err != nil {
 				return fmt.Errorf("resolve env %s: %w", name, err)
@@ -92,6 +128,24 @@ func NewCommand(thunk bass.Thunk) (Command, error) {
 		}
 
 		sort.Strings(cmd.Env)
+		sort.Slice(cmd.SecretEnv, func(i, j int) bool {
+			return cmd.SecretEnv[i].Name < cmd.SecretEnv[j].Name
+		})
+	}
+
+	if thunk.Limits != nil {
+		memory, err := thunk.Limits.MemoryBytes()
+		if err != nil {
+			return Command{}, err
+		}
+
+		if memory > 0 || thunk.Limits.CPUs > 0 || thunk.Limits.Pids > 0 {
+			cmd.Limits = &CommandLimits{
+				Memory: memory,
+				CPUs:   thunk.Limits.CPUs,
+				Pids:   thunk.Limits.Pids,
+			}
+		}
 	}
 
 	if thunk.Stdin != nil {
@@ -117,6 +171,7 @@ func NewCommand(thunk bass.Thunk) (Command, error) {
 			cmd.Mounts = append(cmd.Mounts, CommandMount{
 				Source: m.Source,
 				Target: m.Target.FilesystemPath().FromSlash(),
+				Owner:  m.Owner,
 			})
 		}
 	}
@@ -130,6 +185,9 @@ func (cmd Command) Equal(other Command) bool {
 	return cmp.Equal(cmd.Args, other.Args) &&
 		cmp.Equal(cmd.Stdin, other.Stdin) &&
 		cmp.Equal(cmd.Env, other.Env) &&
+		cmp.Equal(cmd.SecretEnv, other.SecretEnv, cmp.Comparer(func(a, b bass.Secret) bool {
+			return a.Name == b.Name && a.Equal(b)
+		})) &&
 		cmp.Equal(cmd.Dir, other.Dir) &&
 		cmp.Equal(cmd.Mounts, other.Mounts)
 }
@@ -1,6 +1,7 @@
 package runtimes_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/vito/bass/pkg/bass"
@@ -190,6 +191,32 @@ func TestNewCommand(t *testing.T) {
 		})
 	})
 
+	secretEnvThunk := thunk
+	secretEnvThunk.Env = bass.Bindings{
+		"FOO":   bass.String("bar"),
+		"TOKEN": bass.NewSecret("token", []byte("s3cret")),
+	}.Scope()
+
+	t.Run("secrets in env", func(t *testing.T) {
+		is := is.New(t)
+		cmd, err := runtimes.NewCommand(secretEnvThunk)
+		is.NoErr(err)
+		is.Equal(cmd, runtimes.Command{
+			Args: []string{"run"},
+			Env:  []string{"FOO=bar"},
+			SecretEnv: []runtimes.CommandSecretEnv{
+				{
+					Name:   "TOKEN",
+					Secret: bass.NewSecret("token", []byte("s3cret")),
+				},
+			},
+		})
+
+		payload, err := bass.MarshalJSON(cmd)
+		is.NoErr(err)
+		is.True(!strings.Contains(string(payload), "s3cret"))
+	})
+
 	dirWlpWl := thunk
 	dirWlpWl.Dir = &bass.ThunkDir{
 		ThunkDir: &wlDir,
@@ -278,6 +305,59 @@ func TestNewCommand(t *testing.T) {
 			},
 		})
 	})
+
+	ownedWl := thunk
+	ownedWl.User = "1000:1000"
+	ownedWl.Mounts = []bass.ThunkMount{
+		{
+			Source: bass.ThunkMountSource{
+				ThunkPath: &wlDir,
+			},
+			Target: bass.FileOrDirPath{
+				Dir: &bass.DirPath{Path: "dir"},
+			},
+			Owner: "1000",
+		},
+	}
+
+	limitsWl := thunk
+	limitsWl.Limits = &bass.ThunkLimits{
+		Memory:    "1GB",
+		CPUs:      2,
+		TmpfsSize: "64MB",
+	}
+
+	t.Run("limits", func(t *testing.T) {
+		is := is.New(t)
+		cmd, err := runtimes.NewCommand(limitsWl)
+		is.NoErr(err)
+		is.Equal(cmd, runtimes.Command{
+			Args: []string{"run"},
+			Limits: &runtimes.CommandLimits{
+				Memory: 1024 * 1024 * 1024,
+				CPUs:   2,
+			},
+		})
+	})
+
+	t.Run("user and mount owner", func(t *testing.T) {
+		is := is.New(t)
+		cmd, err := runtimes.NewCommand(ownedWl)
+		is.NoErr(err)
+		is.Equal(cmd, runtimes.Command{
+			Args: []string{"run"},
+			User: "1000:1000",
+			Mounts: []runtimes.CommandMount{
+				{
+					Source: bass.ThunkMountSource{
+						ThunkPath: &wlDir,
+					},
+					Target: "./dir/",
+					Owner:  "1000",
+				},
+			},
+		})
+	})
 }
 
 func TestNewCommandInDir(t *testing.T) {
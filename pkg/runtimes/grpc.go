@@ -9,10 +9,12 @@ import (
 
 	"github.com/opencontainers/go-digest"
 	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/ioctx"
 	"github.com/vito/bass/pkg/proto"
 	"github.com/vito/progrock"
 	"github.com/vito/progrock/graph"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -177,8 +179,122 @@ func (client *Client) ExportPath(ctx context.Context, w io.Writer, tp bass.Thunk
 	return nil
 }
 
-func (client *Client) Prune(context.Context, bass.PruneOpts) error {
-	return fmt.Errorf("Prune unimplemented")
+func (client *Client) Publish(ctx context.Context, ref bass.ThunkImageRef, thunk bass.Thunk) (bass.ThunkImageRef, error) {
+	rp, err := ref.MarshalProto()
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	tp, err := thunk.MarshalProto()
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	r, err := client.RuntimeClient.Publish(ctx, &proto.PublishRequest{
+		Ref:   rp.(*proto.ThunkImageRef),
+		Thunk: tp.(*proto.Thunk),
+	})
+	if err != nil {
+		return bass.ThunkImageRef{}, err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+
+	var published *bass.ThunkImageRef
+	for {
+		pov, err := r.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return bass.ThunkImageRef{}, err
+		}
+
+		switch x := pov.GetInner().(type) {
+		case *proto.PublishResponse_Progress:
+			recorder.Record(progressToStatus(x.Progress))
+
+		case *proto.PublishResponse_Published:
+			published = &bass.ThunkImageRef{}
+			if err := published.UnmarshalProto(x.Published); err != nil {
+				return bass.ThunkImageRef{}, err
+			}
+
+		default:
+			return bass.ThunkImageRef{}, fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+
+	if published == nil {
+		return bass.ThunkImageRef{}, fmt.Errorf("no published ref received")
+	}
+
+	return *published, nil
+}
+
+func (client *Client) Prune(ctx context.Context, opts bass.PruneOpts) error {
+	r, err := client.RuntimeClient.Prune(ctx, &proto.PruneRequest{
+		All:          opts.All,
+		KeepDuration: durationpb.New(opts.KeepDuration),
+		KeepBytes:    opts.KeepBytes,
+	})
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.RecorderFromContext(ctx)
+	stderr := ioctx.StderrFromContext(ctx)
+
+	for {
+		pov, err := r.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		switch x := pov.GetInner().(type) {
+		case *proto.PruneResponse_Progress:
+			recorder.Record(progressToStatus(x.Progress))
+
+		case *proto.PruneResponse_Stderr:
+			_, err := stderr.Write(x.Stderr)
+			if err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unhandled stream message: %T", x)
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) DiskUsage(ctx context.Context) ([]bass.DiskUsage, error) {
+	res, err := client.RuntimeClient.DiskUsage(ctx, &proto.DiskUsageRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]bass.DiskUsage, len(res.GetUsage()))
+	for i, du := range res.GetUsage() {
+		usage[i] = bass.DiskUsage{
+			ID:          du.GetId(),
+			Description: du.GetDescription(),
+			Size:        du.GetSize(),
+			UsageCount:  int(du.GetUsageCount()),
+		}
+
+		if du.LastUsedAt != nil {
+			usage[i].LastUsedAt = timePtr(du.LastUsedAt.AsTime())
+		}
+	}
+
+	return usage, nil
 }
 
 func (client *Client) Close() error {
@@ -264,6 +380,76 @@ func (srv *Server) ExportPath(p *proto.ThunkPath, exportSrv proto.Runtime_Export
 	return srv.Runtime.ExportPath(ctx, runSrvBytesWriter{exportSrv}, tp)
 }
 
+func (srv *Server) Publish(p *proto.PublishRequest, publishSrv proto.Runtime_PublishServer) error {
+	ref := bass.ThunkImageRef{}
+	err := ref.UnmarshalProto(p.GetRef())
+	if err != nil {
+		return err
+	}
+
+	thunk := bass.Thunk{}
+	err = thunk.UnmarshalProto(p.GetThunk())
+	if err != nil {
+		return err
+	}
+
+	recorder := progrock.NewRecorder(publishSrvRecorder{publishSrv})
+	ctx := progrock.RecorderToContext(context.Background(), recorder)
+
+	published, err := srv.Runtime.Publish(ctx, ref, thunk)
+	if err != nil {
+		return err
+	}
+
+	pp, err := published.MarshalProto()
+	if err != nil {
+		return err
+	}
+
+	return publishSrv.Send(&proto.PublishResponse{
+		Inner: &proto.PublishResponse_Published{
+			Published: pp.(*proto.ThunkImageRef),
+		},
+	})
+}
+
+func (srv *Server) Prune(p *proto.PruneRequest, pruneSrv proto.Runtime_PruneServer) error {
+	recorder := progrock.NewRecorder(pruneSrvRecorder{pruneSrv})
+	ctx := progrock.RecorderToContext(context.Background(), recorder)
+	ctx = ioctx.StderrToContext(ctx, pruneSrvWriter{pruneSrv})
+
+	return srv.Runtime.Prune(ctx, bass.PruneOpts{
+		All:          p.GetAll(),
+		KeepDuration: p.GetKeepDuration().AsDuration(),
+		KeepBytes:    p.GetKeepBytes(),
+	})
+}
+
+func (srv *Server) DiskUsage(ctx context.Context, p *proto.DiskUsageRequest) (*proto.DiskUsageResponse, error) {
+	usage, err := srv.Runtime.DiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.DiskUsageResponse{}
+	for _, du := range usage {
+		pdu := &proto.DiskUsage{
+			Id:          du.ID,
+			Description: du.Description,
+			Size:        du.Size,
+			UsageCount:  int64(du.UsageCount),
+		}
+
+		if du.LastUsedAt != nil {
+			pdu.LastUsedAt = timestamppb.New(*du.LastUsedAt)
+		}
+
+		res.Usage = append(res.Usage, pdu)
+	}
+
+	return res, nil
+}
+
 type runSrvRecorder struct {
 	runSrv proto.Runtime_RunServer
 }
@@ -308,6 +494,50 @@ func (w readSrvWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+type publishSrvRecorder struct {
+	publishSrv proto.Runtime_PublishServer
+}
+
+func (w publishSrvRecorder) WriteStatus(status *graph.SolveStatus) {
+	w.publishSrv.Send(&proto.PublishResponse{
+		Inner: &proto.PublishResponse_Progress{
+			Progress: statusToProgress(status),
+		},
+	})
+}
+
+func (w publishSrvRecorder) Close() {}
+
+type pruneSrvRecorder struct {
+	pruneSrv proto.Runtime_PruneServer
+}
+
+func (w pruneSrvRecorder) WriteStatus(status *graph.SolveStatus) {
+	w.pruneSrv.Send(&proto.PruneResponse{
+		Inner: &proto.PruneResponse_Progress{
+			Progress: statusToProgress(status),
+		},
+	})
+}
+
+func (w pruneSrvRecorder) Close() {}
+
+type pruneSrvWriter struct {
+	pruneSrv proto.Runtime_PruneServer
+}
+
+func (w pruneSrvWriter) Write(p []byte) (int, error) {
+	err := w.pruneSrv.Send(&proto.PruneResponse{
+		Inner: &proto.PruneResponse_Stderr{
+			Stderr: p,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
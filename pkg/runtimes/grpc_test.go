@@ -0,0 +1,157 @@
+package runtimes_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/ioctx"
+	"github.com/vito/bass/pkg/proto"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type pruneRuntime struct {
+	bass.Runtime
+
+	Pruned []bass.PruneOpts
+	Usage  []bass.DiskUsage
+}
+
+func (runtime *pruneRuntime) Prune(ctx context.Context, opts bass.PruneOpts) error {
+	runtime.Pruned = append(runtime.Pruned, opts)
+	fmt.Fprintln(ioctx.StderrFromContext(ctx), "pruned some stuff")
+	return nil
+}
+
+func (runtime *pruneRuntime) DiskUsage(context.Context) ([]bass.DiskUsage, error) {
+	return runtime.Usage, nil
+}
+
+func TestGRPCPrune(t *testing.T) {
+	is := is.New(t)
+
+	lastUsed := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	runtime := &pruneRuntime{
+		Usage: []bass.DiskUsage{
+			{
+				ID:          "some-id",
+				Description: "some description",
+				Size:        42,
+				UsageCount:  3,
+				LastUsedAt:  &lastUsed,
+			},
+			{
+				ID:   "unused",
+				Size: 1,
+			},
+		},
+	}
+
+	client := serveRuntime(t, runtime)
+
+	stderr := new(bytes.Buffer)
+	ctx := ioctx.StderrToContext(context.Background(), stderr)
+
+	opts := bass.PruneOpts{
+		KeepDuration: time.Hour,
+		KeepBytes:    1024,
+	}
+
+	is.NoErr(client.Prune(ctx, opts))
+	is.Equal(runtime.Pruned, []bass.PruneOpts{opts})
+	is.Equal(stderr.String(), "pruned some stuff\n")
+
+	usage, err := client.DiskUsage(ctx)
+	is.NoErr(err)
+	is.Equal(usage, runtime.Usage)
+}
+
+type publishRuntime struct {
+	bass.Runtime
+
+	Published []bass.Thunk
+}
+
+func (runtime *publishRuntime) Publish(_ context.Context, ref bass.ThunkImageRef, thunk bass.Thunk) (bass.ThunkImageRef, error) {
+	runtime.Published = append(runtime.Published, thunk)
+	ref.Digest = "sha256:" + strings.Repeat("a", 64)
+	return ref, nil
+}
+
+func TestGRPCPublish(t *testing.T) {
+	is := is.New(t)
+
+	runtime := &publishRuntime{}
+
+	client := serveRuntime(t, runtime)
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: "alpine",
+			},
+		},
+		Cmd: bass.ThunkCmd{
+			Cmd: &bass.CommandPath{Command: "echo"},
+		},
+	}
+
+	ref := bass.ThunkImageRef{
+		Platform:   bass.LinuxPlatform,
+		Repository: "registry.example.com/app",
+		Tag:        "tag",
+	}
+
+	published, err := client.Publish(context.Background(), ref, thunk)
+	is.NoErr(err)
+
+	ref.Digest = "sha256:" + strings.Repeat("a", 64)
+	is.Equal(published, ref)
+	is.Equal(len(runtime.Published), 1)
+
+	sha2, err := runtime.Published[0].SHA256()
+	is.NoErr(err)
+
+	expected, err := thunk.SHA256()
+	is.NoErr(err)
+	is.Equal(sha2, expected)
+}
+
+func serveRuntime(t *testing.T, runtime bass.Runtime) *runtimes.Client {
+	is := is.New(t)
+
+	l := bufconn.Listen(1024 * 1024)
+
+	srv := grpc.NewServer()
+	proto.RegisterRuntimeServer(srv, &runtimes.Server{
+		Runtime: runtime,
+	})
+
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return l.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	is.NoErr(err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	return &runtimes.Client{
+		Conn:          conn,
+		RuntimeClient: proto.NewRuntimeClient(conn),
+	}
+}
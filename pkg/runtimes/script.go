@@ -0,0 +1,448 @@
+package runtimes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vito/bass/pkg/bass"
+)
+
+// scriptStep is a single thunk in a chain, resolved to the values needed to
+// render it as a build step.
+type scriptStep struct {
+	Thunk   bass.Thunk
+	Command Command
+
+	// Copies contains host paths to copy into the working directory prior to
+	// running the command.
+	Copies []scriptCopy
+
+	// Unsupported contains notes for anything about the thunk which cannot be
+	// expressed.
+	Unsupported []string
+}
+
+type scriptCopy struct {
+	Source bass.HostPath
+	Target string
+	Owner  string
+}
+
+// scriptChain resolves the thunk and each thunk in its image chain into
+// steps, starting from the base image.
+func scriptChain(thunk bass.Thunk) (*bass.ThunkImageRef, []scriptStep, []string, error) {
+	var steps []scriptStep
+	var unsupported []string
+
+	cur := thunk
+	for {
+		step, err := newScriptStep(cur)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		steps = append([]scriptStep{step}, steps...)
+
+		if cur.Image == nil {
+			return nil, steps, unsupported, nil
+		}
+
+		if cur.Image.Ref != nil {
+			ref := cur.Image.Ref
+			if ref.File != nil {
+				unsupported = append(unsupported, fmt.Sprintf("base image is loaded from an OCI archive: %s", ref.File))
+			}
+
+			return ref, steps, unsupported, nil
+		}
+
+		if cur.Image.DockerBuild != nil {
+			unsupported = append(unsupported, fmt.Sprintf("base image is built from a Dockerfile: %s", cur.Image.DockerBuild.DockerfilePath()))
+			return nil, steps, unsupported, nil
+		}
+
+		cur = *cur.Image.Thunk
+	}
+}
+
+func newScriptStep(thunk bass.Thunk) (scriptStep, error) {
+	step := scriptStep{
+		Thunk: thunk,
+	}
+
+	if thunk.Insecure {
+		step.Unsupported = append(step.Unsupported, "runs in insecure (privileged) mode")
+	}
+
+	if thunk.Limits != nil {
+		step.Unsupported = append(step.Unsupported, "runs with resource limits")
+	}
+
+	if thunk.ImageEnv {
+		step.Unsupported = append(step.Unsupported, "runs as its image's user and through its image's entrypoint")
+	}
+
+	if len(thunk.Capabilities) > 0 {
+		step.Unsupported = append(step.Unsupported, fmt.Sprintf("runs with capabilities: %s", strings.Join(thunk.Capabilities, ", ")))
+	}
+
+	if len(thunk.Devices) > 0 {
+		step.Unsupported = append(step.Unsupported, fmt.Sprintf("runs with devices: %s", strings.Join(thunk.Devices, ", ")))
+	}
+
+	// never render secret values; note them instead
+	omitSecrets := func(val bass.Value) (bass.Value, error) {
+		return bass.Resolve(val, func(v bass.Value) (bass.Value, error) {
+			var secret bass.Secret
+			if err := v.Decode(&secret); err == nil {
+				step.Unsupported = append(step.Unsupported, fmt.Sprintf("secret %s is omitted", secret.Name))
+				return bass.String(fmt.Sprintf("<secret: %s>", secret.Name)), nil
+			}
+
+			return v, nil
+		})
+	}
+
+	// copy to avoid modifying the caller's thunk
+	thunk.Args = append([]bass.Value{}, thunk.Args...)
+	thunk.Stdin = append([]bass.Value{}, thunk.Stdin...)
+
+	var err error
+	for i, arg := range thunk.Args {
+		thunk.Args[i], err = omitSecrets(arg)
+		if err != nil {
+			return scriptStep{}, err
+		}
+	}
+
+	for i, val := range thunk.Stdin {
+		thunk.Stdin[i], err = omitSecrets(val)
+		if err != nil {
+			return scriptStep{}, err
+		}
+	}
+
+	if thunk.Env != nil {
+		env, err := omitSecrets(thunk.Env)
+		if err != nil {
+			return scriptStep{}, err
+		}
+
+		err = env.Decode(&thunk.Env)
+		if err != nil {
+			return scriptStep{}, err
+		}
+	}
+
+	step.Command, err = NewCommand(thunk)
+	if err != nil {
+		return scriptStep{}, err
+	}
+
+	for _, mount := range step.Command.Mounts {
+		target := mount.Target
+		if !filepath.IsAbs(target) {
+			target = path.Join(workDir, filepath.ToSlash(target))
+		}
+
+		src := mount.Source
+		switch {
+		case src.HostPath != nil:
+			step.Copies = append(step.Copies, scriptCopy{
+				Source: *src.HostPath,
+				Target: target,
+				Owner:  mount.Owner,
+			})
+		case src.ThunkPath != nil:
+			step.Unsupported = append(step.Unsupported, fmt.Sprintf("thunk path from another chain mounted to %s: %s", target, src.ThunkPath))
+		case src.FSPath != nil:
+			step.Unsupported = append(step.Unsupported, fmt.Sprintf("embedded path mounted to %s: %s", target, src.FSPath))
+		case src.Cache != nil:
+			step.Unsupported = append(step.Unsupported, fmt.Sprintf("cache mounted to %s: %s", target, src.Cache.Slash()))
+		case src.Secret != nil:
+			step.Unsupported = append(step.Unsupported, fmt.Sprintf("secret %s mounted to %s", src.Secret.Name, target))
+		}
+	}
+
+	return step, nil
+}
+
+// ExportDockerfile writes a Dockerfile with a step for the thunk and each
+// thunk in its image chain.
+//
+// Anything which cannot be expressed in a Dockerfile is noted in an
+// UNSUPPORTED comment.
+func ExportDockerfile(w io.Writer, thunk bass.Thunk) error {
+	ref, steps, unsupported, err := scriptChain(thunk)
+	if err != nil {
+		return err
+	}
+
+	context, contextUnsupported := contextNotes(steps)
+
+	fmt.Fprintf(w, "# generated from %s\n", thunk)
+	if context != "" {
+		fmt.Fprintf(w, "# build context: %s\n", context)
+	}
+
+	writeUnsupported(w, unsupported)
+	writeUnsupported(w, contextUnsupported)
+
+	if ref != nil && ref.Repository != "" {
+		from, err := ref.Ref()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "FROM %s\n", from)
+	} else {
+		fmt.Fprintln(w, "FROM scratch")
+	}
+
+	fmt.Fprintf(w, "WORKDIR %s\n", workDir)
+
+	// thunks run as root unless configured otherwise
+	user := "root"
+	for _, step := range steps {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "# %s\n", step.Thunk.Cmdline())
+		writeUnsupported(w, step.Unsupported)
+
+		for _, cp := range step.Copies {
+			if cp.Owner != "" {
+				fmt.Fprintf(w, "COPY --chown=%s %s %s\n", cp.Owner, contextPath(cp.Source), cp.Target)
+			} else {
+				fmt.Fprintf(w, "COPY %s %s\n", contextPath(cp.Source), cp.Target)
+			}
+		}
+
+		stepUser := step.Command.User
+		if stepUser == "" {
+			stepUser = "root"
+		}
+
+		if stepUser != user {
+			fmt.Fprintf(w, "USER %s\n", stepUser)
+			user = stepUser
+		}
+
+		run := "RUN"
+		if step.Thunk.Network != "" {
+			run += " --network=" + step.Thunk.Network
+		}
+
+		if step.Thunk.SSH {
+			run += " --mount=type=ssh"
+		}
+
+		fmt.Fprintf(w, "%s %s\n", run, shellCommand(step.Command))
+	}
+
+	if thunk.ImageConfig != nil {
+		fmt.Fprintln(w)
+
+		err := writeImageConfig(w, *thunk.ImageConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeImageConfig writes the instructions for configuring the image.
+func writeImageConfig(w io.Writer, config bass.ThunkImageConfig) error {
+	env, err := config.EnvStrings()
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range env {
+		name, val, _ := strings.Cut(kv, "=")
+		fmt.Fprintf(w, "ENV %s=%s\n", name, strconv.Quote(val))
+	}
+
+	if config.Labels != nil {
+		err := config.Labels.Each(func(name bass.Symbol, val bass.Value) error {
+			var str string
+			if err := val.Decode(&str); err != nil {
+				return fmt.Errorf("label %s: %w", name, err)
+			}
+
+			fmt.Fprintf(w, "LABEL %s=%s\n", strconv.Quote(name.String()), strconv.Quote(str))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.User != "" {
+		fmt.Fprintf(w, "USER %s\n", config.User)
+	}
+
+	for _, port := range config.Ports() {
+		fmt.Fprintf(w, "EXPOSE %s\n", port)
+	}
+
+	if len(config.Entrypoint) > 0 {
+		payload, err := json.Marshal(config.Entrypoint)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "ENTRYPOINT %s\n", payload)
+	}
+
+	if len(config.Cmd) > 0 {
+		payload, err := json.Marshal(config.Cmd)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "CMD %s\n", payload)
+	}
+
+	return nil
+}
+
+// ExportShellScript writes a shell script with a step for the thunk and each
+// thunk in its image chain. The script is meant to be run in a container of
+// the base image, from a directory containing the host paths it copies.
+//
+// Anything which cannot be expressed in a shell script is noted in an
+// UNSUPPORTED comment.
+func ExportShellScript(w io.Writer, thunk bass.Thunk) error {
+	ref, steps, unsupported, err := scriptChain(thunk)
+	if err != nil {
+		return err
+	}
+
+	context, contextUnsupported := contextNotes(steps)
+
+	fmt.Fprintln(w, "#!/bin/sh")
+	fmt.Fprintf(w, "# generated from %s\n", thunk)
+
+	if ref != nil && ref.Repository != "" {
+		from, err := ref.Ref()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "# run in image: %s\n", from)
+	}
+
+	if context != "" {
+		fmt.Fprintf(w, "# run from: %s\n", context)
+	}
+
+	writeUnsupported(w, unsupported)
+	writeUnsupported(w, contextUnsupported)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "set -e -x")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `context="$(pwd)"`)
+	fmt.Fprintf(w, "mkdir -p %s\n", workDir)
+
+	for _, step := range steps {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "# %s\n", step.Thunk.Cmdline())
+		writeUnsupported(w, step.Unsupported)
+
+		if step.Command.User != "" {
+			writeUnsupported(w, []string{fmt.Sprintf("runs as user %s", step.Command.User)})
+		}
+
+		if step.Thunk.Network != "" {
+			writeUnsupported(w, []string{fmt.Sprintf("runs with network mode %s", step.Thunk.Network)})
+		}
+
+		if step.Thunk.SSH {
+			writeUnsupported(w, []string{"forwards the host's SSH agent"})
+		}
+
+		for _, cp := range step.Copies {
+			fmt.Fprintf(w, "mkdir -p %s\n", shellQuote(path.Dir(strings.TrimSuffix(cp.Target, "/"))))
+			fmt.Fprintf(w, "cp -R \"$context\"/%s %s\n", shellQuote(contextPath(cp.Source)), shellQuote(cp.Target))
+
+			if cp.Owner != "" {
+				fmt.Fprintf(w, "chown -R %s %s\n", shellQuote(cp.Owner), shellQuote(cp.Target))
+			}
+		}
+
+		fmt.Fprintf(w, "(cd %s && %s)\n", workDir, shellCommand(step.Command))
+	}
+
+	return nil
+}
+
+func writeUnsupported(w io.Writer, notes []string) {
+	for _, note := range notes {
+		fmt.Fprintf(w, "# UNSUPPORTED: %s\n", note)
+	}
+}
+
+// contextPath returns the host path relative to its context directory.
+func contextPath(host bass.HostPath) string {
+	return filepath.ToSlash(filepath.Clean(host.Path.FilesystemPath().FromSlash()))
+}
+
+// contextNotes returns a note naming the context directory that host paths
+// are copied from, along with notes for any host paths that are not in that
+// directory.
+func contextNotes(steps []scriptStep) (string, []string) {
+	var context string
+	var unsupported []string
+	for _, step := range steps {
+		for _, cp := range step.Copies {
+			if context == "" {
+				context = cp.Source.ContextDir
+			} else if cp.Source.ContextDir != context {
+				unsupported = append(unsupported, fmt.Sprintf("host path from a different context directory: %s", cp.Source))
+			}
+		}
+	}
+
+	return context, unsupported
+}
+
+// shellCommand renders the command as a single line of shell.
+func shellCommand(cmd Command) string {
+	var words []string
+
+	if cmd.Dir != nil {
+		words = append(words, "cd", shellQuote(*cmd.Dir), "&&")
+	}
+
+	if len(cmd.Stdin) > 0 {
+		words = append(words, "printf", "'%s'", shellQuote(string(cmd.Stdin)), "|")
+	}
+
+	for _, env := range cmd.Env {
+		name, val, _ := strings.Cut(env, "=")
+		words = append(words, name+"="+shellQuote(val))
+	}
+
+	for _, arg := range cmd.Args {
+		words = append(words, shellQuote(arg))
+	}
+
+	return strings.Join(words, " ")
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+func shellQuote(str string) string {
+	if shellSafe.MatchString(str) {
+		return str
+	}
+
+	return "'" + strings.ReplaceAll(str, "'", `'"'"'`) + "'"
+}
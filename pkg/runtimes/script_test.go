@@ -0,0 +1,187 @@
+package runtimes_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vito/bass/pkg/bass"
+	"github.com/vito/bass/pkg/runtimes"
+	"github.com/vito/is"
+)
+
+func TestExportScripts(t *testing.T) {
+	is := is.New(t)
+
+	base := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: "alpine",
+				Tag:        "3.16",
+			},
+		},
+		Cmd:  bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "apk"}},
+		Args: []bass.Value{bass.String("add"), bass.String("git")},
+	}
+
+	src := bass.NewHostPath("/src", bass.ParseFileOrDirPath("./script.sh"))
+	cache := bass.ParseFileOrDirPath("/cache/go/")
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &base},
+		Cmd:   bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "sh"}},
+		Args:  []bass.Value{src, bass.String("it's")},
+		Env: bass.Bindings{
+			"FOO":   bass.String("bar baz"),
+			"TOKEN": bass.NewSecret("token", []byte("s3cret")),
+		}.Scope(),
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{Cache: &cache},
+				Target: bass.ParseFileOrDirPath("./go/"),
+			},
+		},
+	}
+
+	dockerfile := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportDockerfile(dockerfile, thunk))
+
+	lines := strings.Split(dockerfile.String(), "\n")
+	is.Equal(lines[1], "# build context: /src")
+	is.Equal(lines[2], "FROM alpine:3.16")
+	is.Equal(lines[3], "WORKDIR /bass/work")
+	is.Equal(lines[6], "RUN apk add git")
+	is.Equal(lines[9], "# UNSUPPORTED: secret token is omitted")
+	is.Equal(lines[10], "# UNSUPPORTED: cache mounted to /bass/work/go: /cache/go/")
+	is.True(strings.HasPrefix(lines[11], "COPY script.sh /bass/work/"))
+	is.True(strings.HasPrefix(lines[12], "RUN FOO='bar baz' TOKEN='<secret: token>' sh ./"))
+	is.True(strings.HasSuffix(lines[12], `/script.sh 'it'"'"'s'`))
+
+	script := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportShellScript(script, thunk))
+	is.True(strings.HasPrefix(script.String(), "#!/bin/sh\n"))
+	is.True(strings.Contains(script.String(), "# run in image: alpine:3.16\n"))
+	is.True(strings.Contains(script.String(), "(cd /bass/work && apk add git)\n"))
+	is.True(strings.Contains(script.String(), "# UNSUPPORTED: secret token is omitted\n"))
+
+	for _, out := range []string{dockerfile.String(), script.String()} {
+		is.True(!strings.Contains(out, "s3cret"))
+	}
+}
+
+func TestExportDockerfileImageConfig(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: "alpine",
+			},
+		},
+		Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "true"}},
+		ImageConfig: &bass.ThunkImageConfig{
+			Entrypoint: []string{"/bin/app"},
+			Cmd:        []string{"serve", "--verbose"},
+			Env: bass.Bindings{
+				"PORT": bass.String("8080"),
+			}.Scope(),
+			User: "nobody",
+			Labels: bass.Bindings{
+				"version": bass.String("1.0"),
+			}.Scope(),
+			ExposedPorts: []string{"8080", "53/udp"},
+		},
+	}
+
+	dockerfile := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportDockerfile(dockerfile, thunk))
+	is.True(strings.HasSuffix(dockerfile.String(), strings.Join([]string{
+		"RUN true",
+		"",
+		`ENV PORT="8080"`,
+		`LABEL "version"="1.0"`,
+		"USER nobody",
+		"EXPOSE 8080/tcp",
+		"EXPOSE 53/udp",
+		`ENTRYPOINT ["/bin/app"]`,
+		`CMD ["serve","--verbose"]`,
+		"",
+	}, "\n")))
+}
+
+func TestExportScriptsUser(t *testing.T) {
+	is := is.New(t)
+
+	src := bass.NewHostPath("/src", bass.ParseFileOrDirPath("./app/"))
+
+	base := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: "node",
+			},
+		},
+		Cmd: bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "npm"}},
+		Mounts: []bass.ThunkMount{
+			{
+				Source: bass.ThunkMountSource{HostPath: &src},
+				Target: bass.ParseFileOrDirPath("./app/"),
+				Owner:  "1000:1000",
+			},
+		},
+		User: "1000:1000",
+	}
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{Thunk: &base},
+		Cmd:   bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "ls"}},
+	}
+
+	dockerfile := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportDockerfile(dockerfile, thunk))
+	is.True(strings.HasSuffix(dockerfile.String(), strings.Join([]string{
+		"COPY --chown=1000:1000 app /bass/work/app",
+		"USER 1000:1000",
+		"RUN npm",
+		"",
+		"# ls",
+		"USER root",
+		"RUN ls",
+		"",
+	}, "\n")))
+
+	script := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportShellScript(script, thunk))
+	is.True(strings.Contains(script.String(), "# UNSUPPORTED: runs as user 1000:1000\n"))
+	is.True(strings.Contains(script.String(), "chown -R 1000:1000 /bass/work/app\n"))
+}
+
+func TestExportScriptsNetwork(t *testing.T) {
+	is := is.New(t)
+
+	thunk := bass.Thunk{
+		Image: &bass.ThunkImage{
+			Ref: &bass.ThunkImageRef{
+				Platform:   bass.LinuxPlatform,
+				Repository: "golang",
+			},
+		},
+		Cmd:     bass.ThunkCmd{Cmd: &bass.CommandPath{Command: "go"}},
+		Args:    []bass.Value{bass.String("test")},
+		Network: bass.NetworkNone,
+	}
+
+	dockerfile := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportDockerfile(dockerfile, thunk))
+	is.True(strings.HasSuffix(dockerfile.String(), "RUN --network=none go test\n"))
+
+	dockerfile.Reset()
+	is.NoErr(runtimes.ExportDockerfile(dockerfile, thunk.WithSSH()))
+	is.True(strings.HasSuffix(dockerfile.String(), "RUN --network=none --mount=type=ssh go test\n"))
+
+	script := new(bytes.Buffer)
+	is.NoErr(runtimes.ExportShellScript(script, thunk))
+	is.True(strings.Contains(script.String(), "# UNSUPPORTED: runs with network mode none\n"))
+}
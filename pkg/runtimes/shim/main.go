@@ -14,7 +14,9 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/moby/sys/mountinfo"
@@ -31,6 +33,20 @@ type Command struct {
 	Stdin []byte   `json:"stdin"`
 	Env   []string `json:"env"`
 	Dir   *string  `json:"dir"`
+	User  string   `json:"user,omitempty"`
+
+	Limits    *Limits     `json:"limits,omitempty"`
+	SecretEnv []SecretEnv `json:"secret_env,omitempty"`
+}
+
+type SecretEnv struct {
+	Name string `json:"name"`
+}
+
+type Limits struct {
+	Memory int64 `json:"memory,omitempty"`
+	CPUs   int   `json:"cpus,omitempty"`
+	Pids   int   `json:"pids,omitempty"`
 }
 
 var stdoutPath string
@@ -119,19 +135,82 @@ func run(args []string) int {
 		os.Setenv(segs[0], segs[1])
 	}
 
+	for _, env := range cmd.SecretEnv {
+		// the runtime provides secrets under a private name so they never
+		// appear in the command json
+		secretEnv := "_BASS_SECRET_" + env.Name
+		val, found := os.LookupEnv(secretEnv)
+		if !found {
+			fmt.Fprintf(os.Stderr, "missing secret env: %s\n", env.Name)
+			return 1
+		}
+
+		os.Setenv(env.Name, val)
+		os.Unsetenv(secretEnv)
+	}
+
 	bin := cmd.Args[0]
 	argv := cmd.Args[1:]
 	execCmd := exec.Command(bin, argv...)
 	if cmd.Dir != nil {
 		execCmd.Dir = *cmd.Dir
 	}
+	if cmd.User != "" {
+		cred, home, err := lookupUser(cmd.User)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "lookup user: %s\n", err)
+			return 1
+		}
+
+		// allow the user to write to the working directory
+		err = os.Lchown(".", int(cred.Uid), int(cred.Gid))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chown workdir: %s\n", err)
+			return 1
+		}
+
+		if _, set := os.LookupEnv("HOME"); !set && home != "" {
+			os.Setenv("HOME", home)
+		}
+
+		// allow the user to use a forwarded SSH agent
+		if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" && isSocket(sock) {
+			err = os.Chown(sock, int(cred.Uid), int(cred.Gid))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "chown ssh agent socket: %s\n", err)
+				return 1
+			}
+		}
+
+		execCmd.SysProcAttr = &syscall.SysProcAttr{Credential: cred}
+	}
 	execCmd.Stdin = bytes.NewBuffer(cmd.Stdin)
 	execCmd.Stdout = stdout
 	execCmd.Stderr = os.Stderr
+
+	var cgroup string
+	if cmd.Limits != nil {
+		cgroup, err = limit(*cmd.Limits)
+		if err != nil {
+			// limits are best-effort; Buildkit only lets insecure commands
+			// write to the cgroup filesystem
+			fmt.Fprintf(os.Stderr, "warning: resource limits not enforced: %s\n", err)
+		}
+	}
+
 	err = execCmd.Run()
 	if err != nil {
 		var exit *exec.ExitError
 		if errors.As(err, &exit) {
+			if cgroup != "" {
+				reportLimits(cgroup, *cmd.Limits)
+			}
+
+			if status, ok := exit.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+				fmt.Fprintf(os.Stderr, "killed by signal: %s\n", status.Signal())
+				return 128 + int(status.Signal())
+			}
+
 			// propagate exit status
 			return exit.ExitCode()
 		} else {
@@ -437,3 +516,212 @@ func normalizeTimes(root string, verbose bool) error {
 
 	return nil
 }
+
+// lookupUser resolves a user in the form user[:group] to a credential, along
+// with the user's home directory. The user and group may each be a name or a
+// numeric ID; names are resolved using /etc/passwd and /etc/group.
+func lookupUser(spec string) (*syscall.Credential, string, error) {
+	userSpec, groupSpec, hasGroup := strings.Cut(spec, ":")
+
+	cred := &syscall.Credential{}
+
+	var home string
+	uid, err := strconv.ParseUint(userSpec, 10, 32)
+	numeric := err == nil
+	if numeric {
+		cred.Uid = uint32(uid)
+	}
+
+	entry, found, err := findEntry("/etc/passwd", func(fields []string) bool {
+		if len(fields) < 6 {
+			return false
+		}
+
+		if numeric {
+			return fields[2] == userSpec
+		}
+
+		return fields[0] == userSpec
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if found {
+		uid, err := strconv.ParseUint(entry[2], 10, 32)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed uid for user %s: %w", entry[0], err)
+		}
+
+		gid, err := strconv.ParseUint(entry[3], 10, 32)
+		if err != nil {
+			return nil, "", fmt.Errorf("malformed gid for user %s: %w", entry[0], err)
+		}
+
+		cred.Uid = uint32(uid)
+		cred.Gid = uint32(gid)
+		home = entry[5]
+	} else if !numeric {
+		return nil, "", fmt.Errorf("unknown user: %s", userSpec)
+	}
+
+	if !hasGroup {
+		return cred, home, nil
+	}
+
+	gid, err := strconv.ParseUint(groupSpec, 10, 32)
+	if err == nil {
+		cred.Gid = uint32(gid)
+		return cred, home, nil
+	}
+
+	entry, found, err = findEntry("/etc/group", func(fields []string) bool {
+		return len(fields) >= 3 && fields[0] == groupSpec
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !found {
+		return nil, "", fmt.Errorf("unknown group: %s", groupSpec)
+	}
+
+	gid, err = strconv.ParseUint(entry[2], 10, 32)
+	if err != nil {
+		return nil, "", fmt.Errorf("malformed gid for group %s: %w", entry[0], err)
+	}
+
+	cred.Gid = uint32(gid)
+
+	return cred, home, nil
+}
+
+// isSocket returns true if the path is a Unix socket.
+func isSocket(p string) bool {
+	fi, err := os.Stat(p)
+	return err == nil && fi.Mode()&fs.ModeSocket != 0
+}
+
+// findEntry returns the fields of the first line in a colon-separated file
+// like /etc/passwd which matches. A missing file has no entries.
+func findEntry(file string, match func([]string) bool) ([]string, bool, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Split(line, ":")
+		if match(fields) {
+			return fields, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// limit moves the shim into a new cgroup v2 with the given limits, which will
+// be inherited by the command. It returns the cgroup directory.
+//
+// The cgroup filesystem is typically only writable for insecure thunks, so
+// callers should treat an error as a warning.
+func limit(limits Limits) (string, error) {
+	_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	if err != nil {
+		return "", fmt.Errorf("cgroup v2 unavailable: %w", err)
+	}
+
+	// the cgroup filesystem is mounted read-only, but can be remounted by an
+	// insecure command
+	if unix.Access(cgroupRoot, unix.W_OK) != nil {
+		err := unix.Mount("", cgroupRoot, "", unix.MS_REMOUNT|unix.MS_NOSUID|unix.MS_NOEXEC|unix.MS_NODEV, "")
+		if err != nil {
+			return "", fmt.Errorf("remount cgroup filesystem: %w", err)
+		}
+	}
+
+	cgroup := filepath.Join(cgroupRoot, "bass")
+	err = os.Mkdir(cgroup, 0755)
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("create cgroup: %w", err)
+	}
+
+	// move into the new cgroup first; controllers can only be enabled for
+	// children of a cgroup with no processes
+	err = os.WriteFile(filepath.Join(cgroup, "cgroup.procs"), []byte("0"), 0644)
+	if err != nil {
+		return "", fmt.Errorf("join cgroup: %w", err)
+	}
+
+	settings := map[string]string{}
+	var controllers []string
+	if limits.Memory > 0 {
+		controllers = append(controllers, "+memory")
+		settings["memory.max"] = strconv.FormatInt(limits.Memory, 10)
+	}
+
+	if limits.CPUs > 0 {
+		controllers = append(controllers, "+cpu")
+		settings["cpu.max"] = fmt.Sprintf("%d 100000", limits.CPUs*100000)
+	}
+
+	if limits.Pids > 0 {
+		controllers = append(controllers, "+pids")
+		settings["pids.max"] = strconv.Itoa(limits.Pids)
+	}
+
+	err = os.WriteFile(filepath.Join(cgroupRoot, "cgroup.subtree_control"), []byte(strings.Join(controllers, " ")), 0644)
+	if err != nil {
+		return "", fmt.Errorf("enable controllers: %w", err)
+	}
+
+	for file, val := range settings {
+		err := os.WriteFile(filepath.Join(cgroup, file), []byte(val), 0644)
+		if err != nil {
+			return "", fmt.Errorf("set %s: %w", file, err)
+		}
+	}
+
+	if limits.Memory > 0 {
+		// prefer an OOM kill over swapping; not all kernels support this
+		_ = os.WriteFile(filepath.Join(cgroup, "memory.swap.max"), []byte("0"), 0644)
+	}
+
+	return cgroup, nil
+}
+
+// reportLimits prints a message for each limit that was hit by the command.
+func reportLimits(cgroup string, limits Limits) {
+	if limits.Memory > 0 && cgroupEvent(cgroup, "memory.events", "oom_kill") > 0 {
+		fmt.Fprintf(os.Stderr, "killed: out of memory (limit: %d bytes)\n", limits.Memory)
+	}
+
+	if limits.Pids > 0 && cgroupEvent(cgroup, "pids.events", "max") > 0 {
+		fmt.Fprintf(os.Stderr, "process limit reached (limit: %d)\n", limits.Pids)
+	}
+}
+
+// cgroupEvent returns the count for the event in the cgroup's events file,
+// or 0 if it cannot be read.
+func cgroupEvent(cgroup, file, event string) int {
+	content, err := os.ReadFile(filepath.Join(cgroup, file))
+	if err != nil {
+		return 0
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		name, count, ok := strings.Cut(line, " ")
+		if ok && name == event {
+			n, _ := strconv.Atoi(count)
+			return n
+		}
+	}
+
+	return 0
+}
@@ -5,10 +5,13 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -16,6 +19,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/vito/bass/pkg/bass"
 	. "github.com/vito/bass/pkg/basstest"
 	"github.com/vito/bass/pkg/ioctx"
@@ -197,6 +201,26 @@ func Suite(t *testing.T, pool bass.RuntimePool) {
 			File:   "concat.bass",
 			Result: bass.String("hello, world!\n"),
 		},
+		{
+			File:   "dockerfile.bass",
+			Result: bass.NewList(bass.Int(42), bass.Int(21), bass.Int(1)),
+		},
+		{
+			File:   "limits.bass",
+			Result: bass.NewList(bass.Int(65536), bass.Int(65536)),
+		},
+		{
+			File:   "network.bass",
+			Result: bass.NewList(bass.Int(1), bass.Int(2)),
+		},
+		{
+			File:   "user.bass",
+			Result: bass.NewList(bass.Int(1000), bass.Int(1000), bass.Int(1000)),
+		},
+		{
+			File:   "image-env.bass",
+			Result: bass.NewList(bass.Int(42), bass.Int(1000), bass.Int(0)),
+		},
 	} {
 		test := test
 		t.Run(filepath.Base(test.File), func(t *testing.T) {
@@ -232,6 +256,159 @@ func Suite(t *testing.T, pool bass.RuntimePool) {
 		is.True(cmp.Equal(deadline, time.Now(), cmpopts.EquateApproxTime(10*time.Second)))
 	})
 
+	t.Run("resource limits", func(t *testing.T) {
+		t.Parallel()
+
+		is := is.New(t)
+
+		displayBuf := new(bytes.Buffer)
+		ctx := ioctx.StderrToContext(context.Background(), displayBuf)
+		res, err := RunTest(ctx, t, pool, "limits-enforced.bass", nil)
+		t.Logf("progress:\n%s", displayBuf.String())
+		is.NoErr(err)
+
+		var scp *bass.Scope
+		err = res.Decode(&scp)
+		is.NoErr(err)
+
+		var oom, pids bool
+		var cpus string
+		var unenforced bool
+		is.NoErr(scp.GetDecode("oom", &oom))
+		is.NoErr(scp.GetDecode("pids", &pids))
+		is.NoErr(scp.GetDecode("cpus", &cpus))
+		is.NoErr(scp.GetDecode("unenforced", &unenforced))
+
+		is.True(!oom)
+		is.True(strings.Contains(displayBuf.String(), "killed: out of memory (limit: 67108864 bytes)"))
+
+		is.True(!pids)
+		is.True(strings.Contains(displayBuf.String(), "process limit reached (limit: 4)"))
+
+		is.Equal(cpus, "100000 100000\n")
+
+		// limits which can't be enforced are reported rather than failing
+		is.True(unenforced)
+		is.True(strings.Contains(displayBuf.String(), "warning: resource limits not enforced"))
+	})
+
+	t.Run("privileges", func(t *testing.T) {
+		t.Parallel()
+
+		is := is.New(t)
+
+		displayBuf := new(bytes.Buffer)
+		ctx := ioctx.StderrToContext(context.Background(), displayBuf)
+		policy := bass.PrivilegesConfig{
+			Devices: []string{"/dev/fuse"},
+		}
+
+		res, err := RunTest(bass.WithPrivileges(ctx, policy), t, pool, "privileges.bass", nil)
+		t.Logf("progress:\n%s", displayBuf.String())
+		is.NoErr(err)
+
+		var thunk bass.Thunk
+		err = res.Decode(&thunk)
+		is.NoErr(err)
+
+		runtime, err := pool.Select(*thunk.Platform())
+		is.NoErr(err)
+
+		// the policy is enforced for thunks which bypassed (with-device)
+		err = runtime.Run(ctx, thunk)
+		var notAllowed bass.PrivilegeNotAllowedError
+		is.True(errors.As(err, &notAllowed))
+
+		// Buildkit can't expose individual devices, and must not quietly run
+		// the thunk in insecure mode instead
+		err = runtime.Run(bass.WithPrivileges(ctx, policy), thunk)
+		is.True(err != nil)
+		is.True(strings.Contains(err.Error(), "unsupported by the"))
+	})
+
+	t.Run("image config", func(t *testing.T) {
+		t.Parallel()
+
+		is := is.New(t)
+
+		displayBuf := new(bytes.Buffer)
+		ctx := context.Background()
+		ctx = ioctx.StderrToContext(ctx, displayBuf)
+		res, err := RunTest(ctx, t, pool, "image-config.bass", nil)
+		t.Logf("progress:\n%s", displayBuf.String())
+		is.NoErr(err)
+
+		var thunk bass.Thunk
+		err = res.Decode(&thunk)
+		is.NoErr(err)
+
+		runtime, err := pool.Select(*thunk.Platform())
+		is.NoErr(err)
+
+		buf := new(bytes.Buffer)
+		err = runtime.Export(ctx, buf, thunk)
+		is.NoErr(err)
+
+		image := readImageConfig(t, buf)
+		is.Equal(image.Config.Entrypoint, []string{"/bin/echo"})
+		is.Equal(image.Config.Cmd, []string{"hello"})
+		is.Equal(image.Config.User, "nobody")
+		is.Equal(image.Config.Labels, map[string]string{"version": "1.0"})
+		is.Equal(image.Config.ExposedPorts, map[string]struct{}{"8080/tcp": {}})
+
+		var hasPath bool
+		for _, env := range image.Config.Env {
+			if strings.HasPrefix(env, "PATH=") {
+				// inherited from the base image
+				hasPath = true
+			}
+		}
+
+		is.True(hasPath)
+		is.Equal(image.Config.Env[len(image.Config.Env)-1], "GREETING=hi")
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		registry := os.Getenv("BASS_TEST_REGISTRY")
+		if registry == "" {
+			t.Skip("BASS_TEST_REGISTRY not set; run a registry:2 container to test publishing")
+		}
+
+		t.Parallel()
+
+		is := is.New(t)
+
+		env := bass.Bindings{
+			"REGISTRY": bass.String(registry),
+		}.Scope()
+
+		displayBuf := new(bytes.Buffer)
+		ctx := context.Background()
+		ctx = ioctx.StderrToContext(ctx, displayBuf)
+		res, err := RunTest(ctx, t, pool, "publish.bass", env)
+		t.Logf("progress:\n%s", displayBuf.String())
+		is.NoErr(err)
+		Equal(t, res, bass.Int(42))
+	})
+
+	t.Run("ssh", func(t *testing.T) {
+		if os.Getenv("SSH_AUTH_SOCK") == "" {
+			t.Skip("SSH_AUTH_SOCK not set; run an SSH agent to test forwarding")
+		}
+
+		t.Parallel()
+
+		is := is.New(t)
+
+		displayBuf := new(bytes.Buffer)
+		ctx := context.Background()
+		ctx = ioctx.StderrToContext(ctx, displayBuf)
+		res, err := RunTest(ctx, t, pool, "ssh.bass", nil)
+		t.Logf("progress:\n%s", displayBuf.String())
+		is.NoErr(err)
+		Equal(t, res, bass.NewList(bass.Bool(true), bass.Bool(true), bass.Bool(false)))
+	})
+
 	t.Run("secrets", func(t *testing.T) {
 		t.Parallel()
 
@@ -282,6 +459,45 @@ func Suite(t *testing.T, pool bass.RuntimePool) {
 	})
 }
 
+func readImageConfig(t *testing.T, r io.Reader) ocispecs.Image {
+	is := is.New(t)
+
+	files := map[string][]byte{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		is.NoErr(err)
+
+		content, err := io.ReadAll(tr)
+		is.NoErr(err)
+
+		files[hdr.Name] = content
+	}
+
+	blob := func(desc ocispecs.Descriptor) []byte {
+		content, found := files[path.Join("blobs", desc.Digest.Algorithm().String(), desc.Digest.Encoded())]
+		is.True(found)
+		return content
+	}
+
+	var index ocispecs.Index
+	is.NoErr(json.Unmarshal(files["index.json"], &index))
+	is.Equal(len(index.Manifests), 1)
+
+	var manifest ocispecs.Manifest
+	is.NoErr(json.Unmarshal(blob(index.Manifests[0]), &manifest))
+
+	var image ocispecs.Image
+	is.NoErr(json.Unmarshal(blob(manifest.Config), &image))
+
+	return image
+}
+
 func RunTest(ctx context.Context, t *testing.T, pool bass.RuntimePool, file string, env *bass.Scope) (bass.Value, error) {
 	is := is.New(t)
 